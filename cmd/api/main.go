@@ -14,6 +14,8 @@ import (
 	"os/exec"
 	"os/signal"
 	"runtime"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
@@ -27,6 +29,12 @@ func main() {
 
 	cliMode := flag.Bool("cli", false, "Rodar em modo CLI (terminal) sem servidor web")
 	filePath := flag.String("file", "", "Caminho do arquivo CSV para processar (obrigatório no modo -cli)")
+	nullTokens := flag.String("null-tokens", "", "Marcadores de nulo separados por vírgula (ex.: \"NULL,N/A,-\"). Vazio usa a lista padrão")
+	detectorsPath := flag.String("detectors", "", "Arquivo YAML/JSON com detectores de tipo customizados")
+	ncmTablePath := flag.String("ncm-table", "", "Tabela NCM/TIPI local (JSON do Siscomex ou CSV) para validar colunas NCM")
+	maxOutlierRatio := flag.Float64("outlier-sla", 0, "Fração de outliers (ex.: 0.01) acima da qual colunas numéricas recebem SLA WARNING. Zero desativa")
+	jsonlSchemaLines := flag.Int("jsonl-schema-lines", 0, "Linhas iniciais do JSONL lidas para descobrir os campos (união das chaves). Zero usa o padrão (1000)")
+	jsonMaxDepth := flag.Int("json-max-depth", 0, "Níveis de objetos e arrays JSON aninhados achatados em colunas (address.cep = 2, items[].ncm = 3). Zero usa o padrão (10)")
 
 	flag.Parse()
 
@@ -41,20 +49,66 @@ func main() {
 
 	slog.SetDefault(logger)
 
+	parseOpts := parseOptions(*jsonlSchemaLines, *jsonMaxDepth)
+	var profilerOpts []profiler.Option
+	if *maxOutlierRatio > 0 {
+		profilerOpts = append(profilerOpts, profiler.WithOutlierSLA(*maxOutlierRatio))
+	}
+
+	if *detectorsPath != "" {
+		if err := profiler.LoadDetectorsFile(*detectorsPath); err != nil {
+			logger.Error("Falha ao carregar detectores", "path", *detectorsPath, "error", err)
+			os.Exit(1)
+		}
+	}
+
+	if *ncmTablePath != "" {
+		table, err := profiler.LoadNCMTable(*ncmTablePath)
+		if err != nil {
+			logger.Error("Falha ao carregar tabela NCM", "path", *ncmTablePath, "error", err)
+			os.Exit(1)
+		}
+		profilerOpts = append(profilerOpts, profiler.WithNCMTable(table))
+		logger.Info("Tabela NCM carregada", "path", *ncmTablePath, "codes", len(table))
+	}
+
 	if *cliMode {
 		if *filePath == "" {
 			slog.Error("Erro: No modo -cli, forneça o arquivo: -file=\"dados.csv\"")
 			os.Exit(1)
 		}
-		runCLI(logger, *filePath)
+		runCLI(logger, *filePath, profilerOptions(*nullTokens, profilerOpts), parseOpts)
 		return
 	}
 
-	runServer()
+	runServer(parseOpts, profilerOpts)
+
+}
 
+// parseOptions converte -jsonl-schema-lines e -json-max-depth em opções de
+// leitura. Zero mantém o padrão do infra.
+func parseOptions(jsonlSchemaLines, jsonMaxDepth int) []infra.ParseOption {
+	var opts []infra.ParseOption
+	if jsonlSchemaLines > 0 {
+		opts = append(opts, infra.WithJSONLSchemaLines(jsonlSchemaLines))
+	}
+	if jsonMaxDepth > 0 {
+		opts = append(opts, infra.WithJSONMaxDepth(jsonMaxDepth))
+	}
+	return opts
 }
 
-func runCLI(logger *slog.Logger, path string) {
+// profilerOptions acrescenta às opções vindas das flags (base) a lista de
+// marcadores de nulo (separados por vírgula). Lista vazia mantém o padrão.
+func profilerOptions(nullTokens string, base []profiler.Option) []profiler.Option {
+	opts := slices.Clone(base)
+	if strings.TrimSpace(nullTokens) != "" {
+		opts = append(opts, profiler.WithNullTokens(strings.Split(nullTokens, ",")...))
+	}
+	return opts
+}
+
+func runCLI(logger *slog.Logger, path string, opts []profiler.Option, parseOpts []infra.ParseOption) {
 	start := time.Now()
 
 	logger.Info("CLI: Iniciando DataProfiler", "mode", "streaming", "file", path)
@@ -79,13 +133,13 @@ func runCLI(logger *slog.Logger, path string) {
 		cancel()
 	}()
 
-	headers, dataChan, err := infra.ParseDataAsync(ctx, logger, file)
+	headers, dataChan, err := infra.ParseFileAsync(ctx, logger, file, parseOpts...)
 	if err != nil {
 		logger.Error("Erro crítico na análise do arquivo", "error", err)
 		os.Exit(1)
 	}
 
-	result := profiler.ProfileAsync(logger, headers, dataChan, fileInfo.Name())
+	result := profiler.ProfileAsync(logger, headers, dataChan, fileInfo.Name(), opts...)
 
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -103,7 +157,7 @@ func runCLI(logger *slog.Logger, path string) {
 	)
 }
 
-func runServer() {
+func runServer(parseOpts []infra.ParseOption, profilerOpts []profiler.Option) {
 	sseBroker := web.NewBroker()
 	go func() {
 		slog.Info("🔧 Servidor Debug/Pprof iniciado", "addr", "localhost:6060")
@@ -126,9 +180,11 @@ func runServer() {
 	})
 	mux.Handle("/events", sseBroker)
 	mux.HandleFunc("/api/upload", func(w http.ResponseWriter, r *http.Request) {
-		uploadHandlerStreaming(w, r, sseBroker)
+		uploadHandlerStreaming(w, r, sseBroker, parseOpts, profilerOpts)
+	})
+	mux.HandleFunc("/api/uploadDeprecated", func(w http.ResponseWriter, r *http.Request) {
+		uploadHandlerDeprecated(w, r, profilerOpts)
 	})
-	mux.HandleFunc("/api/uploadDeprecated", uploadHandlerDeprecated)
 
 	handlerComCORS := CORSMiddleware(mux)
 
@@ -222,7 +278,7 @@ func spaHandler(fsys http.FileSystem, fileServer http.Handler) http.Handler {
 	})
 }
 
-func uploadHandlerStreaming(w http.ResponseWriter, r *http.Request, broker *web.Broker) {
+func uploadHandlerStreaming(w http.ResponseWriter, r *http.Request, broker *web.Broker, parseOpts []infra.ParseOption, profilerOpts []profiler.Option) {
 	start := time.Now()
 	requestID := start.UnixNano()
 
@@ -270,7 +326,7 @@ func uploadHandlerStreaming(w http.ResponseWriter, r *http.Request, broker *web.
 		"size_bytes", handler.Size,
 	)
 
-	headers, dataChan, err := infra.ParseDataAsync(ctx, log, progressFile)
+	headers, dataChan, err := infra.ParseDataAsync(ctx, log, progressFile, parseOpts...)
 
 	if err != nil {
 		log.Error("Erro crítico no parser", "error", err)
@@ -278,7 +334,8 @@ func uploadHandlerStreaming(w http.ResponseWriter, r *http.Request, broker *web.
 		return
 	}
 
-	result := profiler.ProfileAsync(log, headers, dataChan, handler.Filename)
+	opts := profilerOptions(r.FormValue("null_tokens"), profilerOpts)
+	result := profiler.ProfileAsync(log, headers, dataChan, handler.Filename, opts...)
 	broker.Broadcast(`{"status": "finishing", "progress": 100}`)
 
 	duration := time.Since(start)
@@ -296,7 +353,7 @@ func uploadHandlerStreaming(w http.ResponseWriter, r *http.Request, broker *web.
 	broker.Broadcast(`{"status": "done", "progress": 100}`)
 }
 
-func uploadHandlerDeprecated(w http.ResponseWriter, r *http.Request) {
+func uploadHandlerDeprecated(w http.ResponseWriter, r *http.Request, profilerOpts []profiler.Option) {
 	start := time.Now()
 	requestID := start.UnixNano()
 
@@ -354,7 +411,7 @@ func uploadHandlerDeprecated(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		log.Info("Parse finalizado. Iniciando Profile Síncrono")
-		result := profiler.Profile(log, columns, handler.Filename)
+		result := profiler.Profile(log, columns, handler.Filename, profilerOptions(r.FormValue("null_tokens"), profilerOpts)...)
 		done <- processingResult{data: result}
 		log.Info("Profile finalizado")
 	}()
@@ -3,3 +3,5 @@ module github.com/JGustavoCN/dataprofiler
 go 1.25.4
 
 require golang.org/x/text v0.32.0
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -4,14 +4,13 @@ import (
 	"bufio"
 	"context"
 	"encoding/csv"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
-	"sort"
+	"slices"
 	"unicode"
 	"unicode/utf8"
 
@@ -94,7 +93,7 @@ func ParseData(logger *slog.Logger, file io.Reader) ([]profiler.Column, error) {
 	return columns, nil
 }
 
-func ParseDataAsync(ctx context.Context, logger *slog.Logger, r io.Reader) ([]string, <-chan profiler.StreamData, error) {
+func ParseDataAsync(ctx context.Context, logger *slog.Logger, r io.Reader, opts ...ParseOption) ([]string, <-chan profiler.StreamData, error) {
 	if logger == nil {
 		logger = slog.New(slog.NewJSONHandler(io.Discard, nil))
 	}
@@ -112,7 +111,7 @@ func ParseDataAsync(ctx context.Context, logger *slog.Logger, r io.Reader) ([]st
 
 	if isJson {
 		logger.Info("Formato detectado: JSONL (Logs/NoSQL)")
-		return parseJSONLAsync(ctx, logger, bufferedSmartReader)
+		return parseJSONLAsync(ctx, logger, bufferedSmartReader, newParseConfig(opts))
 	}
 	logger.Info("Formato detectado: CSV (Tabular)")
 	return parseCSVAsync(ctx, logger, bufferedSmartReader)
@@ -214,7 +213,18 @@ func parseCSVAsync(ctx context.Context, logger *slog.Logger, reader *bufio.Reade
 	return headers, out, nil
 }
 
-func parseJSONLAsync(ctx context.Context, logger *slog.Logger, reader *bufio.Reader) ([]string, <-chan profiler.StreamData, error) {
+type jsonlRecord struct {
+	line  int
+	cells []jsonCell
+	err   error
+}
+
+// parseJSONLAsync lê as primeiras linhas (WithJSONLSchemaLines) antes do
+// streaming: as colunas são a união ordenada dos caminhos achatados
+// (flattenJSON) encontrados nelas. Registros sem algum campo levam o índice
+// dele em StreamData.Missing; elementos de array além do primeiro vão em
+// StreamData.Extra.
+func parseJSONLAsync(ctx context.Context, logger *slog.Logger, reader *bufio.Reader, cfg *parseConfig) ([]string, <-chan profiler.StreamData, error) {
 	out := make(chan profiler.StreamData, 1000)
 
 	scanner := bufio.NewScanner(reader)
@@ -230,46 +240,116 @@ func parseJSONLAsync(ctx context.Context, logger *slog.Logger, reader *bufio.Rea
 		return nil, nil, errors.New("arquivo JSONL vazio")
 	}
 
-	firstLine := scanner.Bytes()
-	var firstMap map[string]interface{}
-	if err := json.Unmarshal(firstLine, &firstMap); err != nil {
+	firstMap, err := decodeJSONLine(scanner.Bytes())
+	if err != nil {
 		return nil, nil, fmt.Errorf("erro de parsing na primeira linha (não é JSON válido?): %w", err)
 	}
 
-	headers := make([]string, 0, len(firstMap))
-	for k := range firstMap {
-		headers = append(headers, k)
+	prefix := []jsonlRecord{{line: 1, cells: flattenJSON(firstMap, cfg.jsonMaxDepth, nil)}}
+	lineNum := 1
+	for len(prefix) < cfg.jsonlSchemaLines && scanner.Scan() {
+		lineNum++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := jsonlRecord{line: lineNum}
+		var fields map[string]interface{}
+		if fields, record.err = decodeJSONLine(scanner.Bytes()); record.err == nil {
+			record.cells = flattenJSON(fields, cfg.jsonMaxDepth, nil)
+		}
+		prefix = append(prefix, record)
 	}
 
-	sort.Strings(headers)
+	columns := make(map[string]int)
+	headers := []string{}
+	for _, record := range prefix {
+		for _, cell := range record.cells {
+			if _, ok := columns[cell.path]; !ok {
+				columns[cell.path] = 0
+				headers = append(headers, cell.path)
+			}
+		}
+	}
+	slices.SortFunc(headers, compareJSONPaths)
+	for i, header := range headers {
+		columns[header] = i
+	}
 
-	logger.Info("Schema JSONL inferido", "headers", headers)
+	logger.Info("Schema JSONL inferido", "headers", headers, "schema_lines", len(prefix))
 
 	go func() {
 		defer close(out)
 
-		processMap := func(m map[string]interface{}, lineNum int) {
+		filled := make([]bool, len(headers))
+		ignored := make(map[string]bool)
+		var cells []jsonCell
+
+		processCells := func(cells []jsonCell, lineNum int) {
 			row := profiler.GetRowSlice()
+			for range headers {
+				row = append(row, "")
+			}
+			kinds := make([]profiler.ValueKind, len(headers))
+			clear(filled)
+
+			var extra []profiler.Cell
+			for _, cell := range cells {
+				i, ok := columns[cell.path]
+				if !ok {
+					// Campos novos depois da descoberta não têm coluna: avisa
+					// uma vez por campo.
+					if !ignored[cell.path] {
+						ignored[cell.path] = true
+						logger.Warn("Campo fora do schema JSONL ignorado",
+							"field", cell.path,
+							"line", lineNum,
+							"schema_lines", cfg.jsonlSchemaLines,
+						)
+					}
+					continue
+				}
+				if filled[i] {
+					extra = append(extra, profiler.Cell{Column: i, Value: cell.value, Kind: cell.kind})
+					continue
+				}
+				filled[i] = true
+				row[i] = cell.value
+				kinds[i] = cell.kind
+			}
 
-			for _, header := range headers {
-				val, exists := m[header]
-				if !exists || val == nil {
-					row = append(row, "")
-				} else {
-					row = append(row, fmt.Sprintf("%v", val))
+			var missing []int
+			for i, ok := range filled {
+				if !ok {
+					missing = append(missing, i)
 				}
 			}
 
 			out <- profiler.StreamData{
 				Row:        row,
 				LineNumber: lineNum,
+				Missing:    missing,
+				Extra:      extra,
+				Kinds:      kinds,
 				Err:        nil,
 			}
 		}
 
-		processMap(firstMap, 1)
+		for _, record := range prefix {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+			if record.err != nil {
+				out <- profiler.StreamData{
+					LineNumber: record.line,
+					Err:        fmt.Errorf("json malformado: %w", record.err),
+				}
+				continue
+			}
+			processCells(record.cells, record.line)
+		}
 
-		lineNum := 1
 		for scanner.Scan() {
 			select {
 			case <-ctx.Done():
@@ -282,8 +362,8 @@ func parseJSONLAsync(ctx context.Context, logger *slog.Logger, reader *bufio.Rea
 				continue
 			}
 
-			var currentMap map[string]interface{}
-			if err := json.Unmarshal(scanner.Bytes(), &currentMap); err != nil {
+			currentMap, err := decodeJSONLine(scanner.Bytes())
+			if err != nil {
 				out <- profiler.StreamData{
 					LineNumber: lineNum,
 					Err:        fmt.Errorf("json malformado: %w", err),
@@ -291,7 +371,8 @@ func parseJSONLAsync(ctx context.Context, logger *slog.Logger, reader *bufio.Rea
 				continue
 			}
 
-			processMap(currentMap, lineNum)
+			cells = flattenJSON(currentMap, cfg.jsonMaxDepth, cells[:0])
+			processCells(cells, lineNum)
 		}
 
 		if err := scanner.Err(); err != nil {
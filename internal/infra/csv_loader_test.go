@@ -7,6 +7,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 
@@ -264,7 +265,7 @@ func TestParseDataAsync_JSONL(t *testing.T) {
 
 	jsonContent := `{"time":"2023-01-01", "level":"INFO", "msg":"Teste 1"}
 {"msg":"Teste 2", "level":"WARN", "time":"2023-01-02"}
-{"msg":"Teste 3", "level":"ERROR", "time":"2023-01-03", "extra":"ignorado"}
+{"msg":"Teste 3", "level":"ERROR", "time":"2023-01-03", "extra":"opcional"}
 `
 
 	reader := strings.NewReader(jsonContent)
@@ -275,9 +276,10 @@ func TestParseDataAsync_JSONL(t *testing.T) {
 		t.Fatalf("Erro ao iniciar parser: %v", err)
 	}
 
-	expectedHeaders := []string{"level", "msg", "time"}
-	if len(headers) != 3 {
-		t.Fatalf("Esperava 3 headers, recebeu %d: %v", len(headers), headers)
+	// O campo que só aparece na linha 3 entra no schema.
+	expectedHeaders := []string{"extra", "level", "msg", "time"}
+	if len(headers) != len(expectedHeaders) {
+		t.Fatalf("Esperava %d headers, recebeu %d: %v", len(expectedHeaders), len(headers), headers)
 	}
 	for i, h := range headers {
 		if h != expectedHeaders[i] {
@@ -291,15 +293,42 @@ func TestParseDataAsync_JSONL(t *testing.T) {
 	}
 
 	if len(rows) != 3 {
-		t.Errorf("Esperava 3 linhas de dados, recebeu %d", len(rows))
+		t.Fatalf("Esperava 3 linhas de dados, recebeu %d", len(rows))
 	}
 
 	row2 := rows[1].Row
-	if row2[0] != "WARN" { // level
-		t.Errorf("Mapeamento incorreto. Coluna 0 (level) deveria ser WARN, foi %s", row2[0])
+	if row2[1] != "WARN" { // level
+		t.Errorf("Mapeamento incorreto. Coluna 1 (level) deveria ser WARN, foi %s", row2[1])
 	}
-	if row2[1] != "Teste 2" { // msg
-		t.Errorf("Mapeamento incorreto. Coluna 1 (msg) deveria ser Teste 2, foi %s", row2[1])
+	if row2[2] != "Teste 2" { // msg
+		t.Errorf("Mapeamento incorreto. Coluna 2 (msg) deveria ser Teste 2, foi %s", row2[2])
+	}
+	if !slices.Equal(rows[1].Missing, []int{0}) {
+		t.Errorf("Linha 2 deveria marcar extra como ausente, marcou %v", rows[1].Missing)
+	}
+	if rows[2].Missing != nil || rows[2].Row[0] != "opcional" {
+		t.Errorf("Linha 3 traz todos os campos: Missing=%v, extra=%q", rows[2].Missing, rows[2].Row[0])
+	}
+}
+
+func TestParseDataAsync_JSONLSchemaLines(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	jsonContent := `{"id":1}
+{"id":2, "nome":"Ana"}
+{"id":3, "cidade":"Recife"}
+`
+
+	headers, dataChan, err := ParseDataAsync(context.Background(), logger, strings.NewReader(jsonContent), WithJSONLSchemaLines(2))
+	if err != nil {
+		t.Fatalf("Erro ao iniciar parser: %v", err)
+	}
+	for range dataChan {
+	}
+
+	// "cidade" só aparece depois das 2 linhas de descoberta.
+	if expected := []string{"id", "nome"}; !slices.Equal(headers, expected) {
+		t.Errorf("Esperava %v, recebeu %v", expected, headers)
 	}
 }
 
@@ -0,0 +1,393 @@
+package infra
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"slices"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/JGustavoCN/dataprofiler/internal/profiler"
+)
+
+// O parser paralelo lê o arquivo em blocos de parallelBlockSize cortados no
+// fim do último registro completo. Arquivos menores que parallelMinFileSize
+// seguem pelo parser sequencial.
+const (
+	parallelBlockSize   = 4 << 20
+	parallelMinFileSize = 16 << 20
+)
+
+// ParseFileAsync equivale ao ParseDataAsync para arquivos locais. CSVs em
+// UTF-8 são divididos em blocos alinhados a registros (respeitando campos
+// entre aspas) e interpretados em paralelo; a ordem das linhas, a numeração
+// e os erros de DirtyLine são os mesmos do parser sequencial. JSONL, outras
+// codificações e arquivos pequenos usam o ParseDataAsync.
+func ParseFileAsync(ctx context.Context, logger *slog.Logger, file *os.File, opts ...ParseOption) ([]string, <-chan profiler.StreamData, error) {
+	if logger == nil {
+		logger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if !info.Mode().IsRegular() || info.Size() < parallelMinFileSize || workers < 2 {
+		return ParseDataAsync(ctx, logger, file, opts...)
+	}
+
+	head := make([]byte, 2048)
+	n, err := file.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	if !isPlainCSV(head[:n]) {
+		return ParseDataAsync(ctx, logger, file, opts...)
+	}
+
+	logger.Info("Formato detectado: CSV (Tabular)", "mode", "parallel")
+	return parseCSVParallel(ctx, logger, file, parallelBlockSize, workers)
+}
+
+// isPlainCSV aplica à amostra inicial as mesmas regras do NewSmartReader e
+// do sniffJSON: só CSV em UTF-8 dispensa a conversão sequencial.
+func isPlainCSV(head []byte) bool {
+	if len(head) >= 2 && (head[0] == 0xFF && head[1] == 0xFE || head[0] == 0xFE && head[1] == 0xFF) {
+		return false
+	}
+	if !utf8.Valid(head) {
+		return false
+	}
+	isJSON, _ := sniffJSON(bufio.NewReader(bytes.NewReader(head)))
+	return !isJSON
+}
+
+type csvBlock struct {
+	index int
+	data  []byte
+	err   error
+}
+
+type parsedBlock struct {
+	index int
+	items []profiler.StreamData // LineNumber relativo ao bloco
+	reads int                   // registros lidos (chamadas a Read)
+	lines int                   // quebras de linha físicas do bloco
+	err   error
+}
+
+func parseCSVParallel(ctx context.Context, logger *slog.Logger, r io.Reader, blockSize, workers int) ([]string, <-chan profiler.StreamData, error) {
+	reader := bufio.NewReaderSize(r, 1024*1024)
+
+	separator, err := DetectSeparator(reader)
+	if err != nil {
+		separator = ';'
+		logger.Warn("Falha na detecção de separador, usando fallback", "error", err, "fallback", separator)
+	} else {
+		logger.Info("Separador detectado", "separator", string(separator))
+	}
+
+	splitter := &recordSplitter{r: reader, separator: byte(separator), blockSize: blockSize}
+	first, err := splitter.next()
+	if err != nil {
+		return nil, nil, err
+	}
+	headerReader := newBlockReader(first, separator)
+	headersRef, err := headerReader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	headers := slices.Clone(headersRef)
+	offset := int(headerReader.InputOffset())
+	logger.Info("Início do streaming",
+		"columns_count", len(headers),
+		"headers", headers,
+		"workers", workers,
+	)
+
+	out := make(chan profiler.StreamData, 1000)
+	go func() {
+		defer close(out)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		blocks := make(chan csvBlock)
+		parsed := make(chan parsedBlock, workers)
+		// Limita os blocos lidos e ainda não emitidos.
+		inflight := make(chan struct{}, 2*workers)
+
+		go func() {
+			defer close(blocks)
+			send := func(block csvBlock) bool {
+				select {
+				case inflight <- struct{}{}:
+				case <-ctx.Done():
+					return false
+				}
+				select {
+				case blocks <- block:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+
+			if !send(csvBlock{index: 0, data: first[offset:]}) {
+				return
+			}
+			for index := 1; ; index++ {
+				data, err := splitter.next()
+				if err == io.EOF {
+					return
+				}
+				if !send(csvBlock{index: index, data: data, err: err}) || err != nil {
+					return
+				}
+			}
+		}()
+
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for block := range blocks {
+					result := parseBlock(block, separator, len(headers))
+					splitter.release(block.data)
+					select {
+					case parsed <- result:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(parsed)
+		}()
+
+		// Emite os blocos na ordem do arquivo, convertendo a numeração local
+		// de cada bloco na do arquivo (cabeçalho = linha 1).
+		pending := make(map[int]parsedBlock)
+		next := 0
+		lineNum := 1
+		physicalLines := bytes.Count(first[:offset], []byte{'\n'})
+		errorCount := 0
+		emit := func(item profiler.StreamData) bool {
+			select {
+			case out <- item:
+				return true
+			case <-ctx.Done():
+				logger.Warn("Leitura cancelada pelo contexto")
+				return false
+			}
+		}
+
+		for block := range parsed {
+			pending[block.index] = block
+			for {
+				b, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+
+				if b.err != nil {
+					errorCount++
+					emit(profiler.StreamData{LineNumber: lineNum + 1, Err: fmt.Errorf("erro de I/O: %w", b.err)})
+					return
+				}
+				for _, item := range b.items {
+					item.LineNumber += lineNum
+					if item.Err != nil {
+						errorCount++
+						item.Err = shiftParseError(item.Err, physicalLines)
+					}
+					if !emit(item) {
+						return
+					}
+				}
+				lineNum += b.reads
+				physicalLines += b.lines
+				<-inflight
+			}
+		}
+
+		logger.Info("Streaming CSV finalizado",
+			"total_rows_read", lineNum-1,
+			"total_errors", errorCount,
+		)
+	}()
+
+	return headers, out, nil
+}
+
+func newBlockReader(data []byte, separator rune) *csv.Reader {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = separator
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+	return reader
+}
+
+func parseBlock(block csvBlock, separator rune, fields int) parsedBlock {
+	result := parsedBlock{index: block.index, err: block.err}
+	if block.err != nil {
+		return result
+	}
+	result.lines = bytes.Count(block.data, []byte{'\n'})
+	result.items = make([]profiler.StreamData, 0, result.lines+1)
+
+	reader := newBlockReader(block.data, separator)
+	reader.FieldsPerRecord = fields
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return result
+		}
+		result.reads++
+		if err != nil {
+			result.items = append(result.items, profiler.StreamData{LineNumber: result.reads, Err: err})
+			continue
+		}
+		row := profiler.GetRowSlice()
+		row = append(row, record...)
+		result.items = append(result.items, profiler.StreamData{Row: row, LineNumber: result.reads})
+	}
+}
+
+// shiftParseError corrige as linhas de um csv.ParseError, contadas a partir
+// do início do bloco.
+func shiftParseError(err error, offset int) error {
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+	shifted := *parseErr
+	shifted.StartLine += offset
+	shifted.Line += offset
+	return &shifted
+}
+
+// recordSplitter lê blocos que começam e terminam em fronteiras de registro.
+// Os buffers dos blocos já interpretados voltam por release.
+type recordSplitter struct {
+	r         io.Reader
+	separator byte
+	blockSize int
+	pending   []byte // início de registro incompleto do bloco anterior
+	eof       bool
+	buffers   sync.Pool
+}
+
+func (s *recordSplitter) buffer(size int) []byte {
+	if buf, ok := s.buffers.Get().(*[]byte); ok && cap(*buf) >= size {
+		return (*buf)[:size]
+	}
+	return make([]byte, size)
+}
+
+func (s *recordSplitter) release(block []byte) {
+	block = block[:0]
+	s.buffers.Put(&block)
+}
+
+// next retorna o próximo bloco. Só o último pode terminar sem quebra de
+// linha; registros maiores que o bloco fazem a leitura crescer.
+func (s *recordSplitter) next() ([]byte, error) {
+	for {
+		if s.eof {
+			if len(s.pending) == 0 {
+				return nil, io.EOF
+			}
+			block := s.pending
+			s.pending = nil
+			return block, nil
+		}
+
+		buf := s.buffer(len(s.pending) + s.blockSize)
+		copied := copy(buf, s.pending)
+		n, err := io.ReadFull(s.r, buf[copied:])
+		buf = buf[:copied+n]
+		switch {
+		case err == io.EOF || err == io.ErrUnexpectedEOF:
+			s.eof = true
+		case err != nil:
+			return nil, err
+		}
+
+		if end := lastRecordEnd(buf, s.separator); end >= 0 {
+			s.pending = append(s.pending[:0], buf[end+1:]...)
+			return buf[:end+1], nil
+		}
+		s.pending = append(s.pending[:0], buf...)
+		s.release(buf)
+	}
+}
+
+// lastRecordEnd retorna a posição da última quebra de linha que encerra um
+// registro, ou -1. data deve começar no início de um registro. Segue as
+// regras do encoding/csv com LazyQuotes: aspas só abrem campo no início dele,
+// e dentro do campo só fecham quando seguidas de separador ou fim de linha
+// ("" é aspa escapada; as demais são literais).
+func lastRecordEnd(data []byte, separator byte) int {
+	last := -1
+	i := 0
+	for i < len(data) {
+		q := bytes.IndexByte(data[i:], '"')
+		if q < 0 {
+			if nl := bytes.LastIndexByte(data[i:], '\n'); nl >= 0 {
+				last = i + nl
+			}
+			return last
+		}
+		q += i
+		if nl := bytes.LastIndexByte(data[i:q], '\n'); nl >= 0 {
+			last = i + nl
+		}
+		i = q + 1
+		if q > 0 && data[q-1] != separator && data[q-1] != '\n' {
+			continue
+		}
+
+		// Campo entre aspas: procura a aspa que o fecha. Se o bloco acabar
+		// antes de ser possível decidir, o resto fica para o próximo bloco.
+		for {
+			c := bytes.IndexByte(data[i:], '"')
+			if c < 0 {
+				return last
+			}
+			after := i + c + 1
+			if after == len(data) {
+				return last
+			}
+			if data[after] == '"' {
+				i = after + 1
+				continue
+			}
+			closes := data[after] == separator || data[after] == '\n'
+			if data[after] == '\r' {
+				if after+1 == len(data) {
+					return last
+				}
+				closes = data[after+1] == '\n'
+			}
+			i = after
+			if closes {
+				break
+			}
+		}
+	}
+	return last
+}
@@ -0,0 +1,94 @@
+package infra
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/JGustavoCN/dataprofiler/internal/profiler"
+)
+
+// O tamanho do arquivo de benchmark vem de DATAPROFILER_BENCH_MB (padrão
+// 256). Para medir num arquivo de vários GB:
+//
+//	DATAPROFILER_BENCH_MB=4096 go test -run '^$' -bench ParseCSVFile -benchtime 3x ./internal/infra
+func benchFixture(b *testing.B) string {
+	b.Helper()
+	sizeMB := 256
+	if env := os.Getenv("DATAPROFILER_BENCH_MB"); env != "" {
+		parsed, err := strconv.Atoi(env)
+		if err != nil {
+			b.Fatalf("DATAPROFILER_BENCH_MB inválido: %v", err)
+		}
+		sizeMB = parsed
+	}
+
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("dataprofiler-bench-%dmb.csv", sizeMB))
+	if info, err := os.Stat(path); err == nil && info.Size() >= int64(sizeMB)<<20 {
+		return path
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer file.Close()
+	w := bufio.NewWriterSize(file, 1<<20)
+	fmt.Fprintln(w, "id;cpf;nome;cidade;valor;data;observacao")
+	for i, written := 0, 0; written < sizeMB<<20; i++ {
+		n, _ := fmt.Fprintf(w, "%d;529.982.247-25;Cliente %d;\"São Paulo; SP\";%d,%02d;%02d/%02d/2024;\"linha com \"\"aspas\"\"\"\n",
+			i, i, i%10000, i%100, i%28+1, i%12+1)
+		written += n
+	}
+	if err := w.Flush(); err != nil {
+		b.Fatal(err)
+	}
+	return path
+}
+
+func BenchmarkParseCSVFile(b *testing.B) {
+	path := benchFixture(b)
+	info, err := os.Stat(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	parsers := []struct {
+		name  string
+		parse func(context.Context, *slog.Logger, *os.File, ...ParseOption) ([]string, <-chan profiler.StreamData, error)
+	}{
+		{"Sequencial", func(ctx context.Context, logger *slog.Logger, f *os.File, opts ...ParseOption) ([]string, <-chan profiler.StreamData, error) {
+			return ParseDataAsync(ctx, logger, f, opts...)
+		}},
+		{"Paralelo", ParseFileAsync},
+	}
+
+	for _, p := range parsers {
+		b.Run(p.name, func(b *testing.B) {
+			b.SetBytes(info.Size())
+			for b.Loop() {
+				file, err := os.Open(path)
+				if err != nil {
+					b.Fatal(err)
+				}
+				_, data, err := p.parse(context.Background(), logger, file)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for item := range data {
+					if item.Row != nil {
+						profiler.PutRowSlice(item.Row)
+					}
+				}
+				file.Close()
+			}
+		})
+	}
+}
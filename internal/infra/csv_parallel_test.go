@@ -0,0 +1,129 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/JGustavoCN/dataprofiler/internal/profiler"
+)
+
+// trickyCSV reúne os casos que dificultam o corte em blocos: separador e
+// quebra de linha entre aspas, aspas escapadas, aspas literais (LazyQuotes),
+// CRLF, linhas em branco, linhas sujas e arquivo sem quebra de linha final.
+func trickyCSV(rows int) string {
+	var sb strings.Builder
+	sb.WriteString("id;descricao;valor\n")
+	for i := range rows {
+		switch i % 9 {
+		case 0:
+			fmt.Fprintf(&sb, "%d;simples;10,50\n", i)
+		case 1:
+			fmt.Fprintf(&sb, "%d;\"com ; separador\";1\n", i)
+		case 2:
+			fmt.Fprintf(&sb, "%d;\"quebra\nde linha\n\";2\n", i)
+		case 3:
+			fmt.Fprintf(&sb, "%d;\"ele disse \"\"oi\"\"\";3\n", i)
+		case 4:
+			fmt.Fprintf(&sb, "%d;monitor 27\" full hd;4\n", i)
+		case 5:
+			fmt.Fprintf(&sb, "%d;\"abc\"def\";5\r\n", i)
+		case 6:
+			fmt.Fprintf(&sb, "\n%d;\"\";6\n", i)
+		case 7:
+			fmt.Fprintf(&sb, "%d;coluna;a;mais\n", i)
+		case 8:
+			fmt.Fprintf(&sb, "%d;\"termina\"\r\n\";8\n", i)
+		}
+	}
+	sb.WriteString("fim;sem quebra;0")
+	return sb.String()
+}
+
+func collectStream(t *testing.T, data <-chan profiler.StreamData) []string {
+	t.Helper()
+	var items []string
+	for item := range data {
+		if item.Err != nil {
+			items = append(items, fmt.Sprintf("%d ERR %v", item.LineNumber, item.Err))
+			continue
+		}
+		items = append(items, fmt.Sprintf("%d %q", item.LineNumber, item.Row))
+	}
+	return items
+}
+
+func TestParseCSVParallel_MatchesSequential(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	content := trickyCSV(2000)
+
+	seqHeaders, seqData, err := ParseDataAsync(context.Background(), logger, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Erro inesperado no parser sequencial: %v", err)
+	}
+	expected := collectStream(t, seqData)
+
+	for _, blockSize := range []int{16, 100, 4096} {
+		t.Run(fmt.Sprintf("bloco de %d bytes", blockSize), func(t *testing.T) {
+			headers, data, err := parseCSVParallel(context.Background(), logger, strings.NewReader(content), blockSize, 4)
+			if err != nil {
+				t.Fatalf("Erro inesperado: %v", err)
+			}
+			if !slices.Equal(headers, seqHeaders) {
+				t.Errorf("Headers = %v; esperava %v", headers, seqHeaders)
+			}
+
+			got := collectStream(t, data)
+			if len(got) != len(expected) {
+				t.Fatalf("Esperava %d itens, recebeu %d", len(expected), len(got))
+			}
+			for i := range expected {
+				if got[i] != expected[i] {
+					t.Fatalf("Item %d difere:\n  paralelo:   %s\n  sequencial: %s", i, got[i], expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCSVParallel_Cancel(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, data, err := parseCSVParallel(ctx, logger, strings.NewReader(trickyCSV(5000)), 64, 4)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	<-data
+	cancel()
+
+	// O canal precisa fechar mesmo sem ser drenado até o fim.
+	for range data {
+	}
+}
+
+func TestLastRecordEnd(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected int
+	}{
+		{"Sem quebra", "a;b", -1},
+		{"Quebra simples", "a;b\nc;d", 3},
+		{"Quebra entre aspas", "a;\"x\ny\"\nc;\"z\nw", 7},
+		{"Aspa literal no meio do campo", "a;5\" tela\nb", 9},
+		{"Aspa ambígua no fim do bloco", "a;\"x\ny\"", -1},
+		{"Aspas escapadas", "a;\"\"\"\n\"\"\"\nb", 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastRecordEnd([]byte(tt.data), ';'); got != tt.expected {
+				t.Errorf("lastRecordEnd(%q) = %d; esperava %d", tt.data, got, tt.expected)
+			}
+		})
+	}
+}
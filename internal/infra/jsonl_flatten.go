@@ -0,0 +1,106 @@
+package infra
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JGustavoCN/dataprofiler/internal/profiler"
+)
+
+// jsonCell é uma folha de um objeto JSON achatado, com o tipo nativo do
+// valor.
+type jsonCell struct {
+	path  string
+	value string
+	kind  profiler.ValueKind
+}
+
+// decodeJSONLine decodifica uma linha JSONL mantendo os números como
+// json.Number: o literal original (1e+06, inteiros acima de 2^53) chega
+// intacto ao profiler.
+func decodeJSONLine(line []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	var record map[string]interface{}
+	if err := decoder.Decode(&record); err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(line[decoder.InputOffset():])) > 0 {
+		return nil, errors.New("conteúdo após o objeto JSON")
+	}
+	return record, nil
+}
+
+// flattenJSON achata um objeto JSON em folhas com caminhos pontuados
+// (address.cep). Elementos de array acrescentam "[]" ao caminho
+// (items[].ncm) e o tamanho de cada array vira a folha len(caminho). Cada
+// objeto e cada array conta um nível: items[].ncm está no nível 3. Objetos e
+// arrays no nível maxDepth, e objetos vazios, viram texto JSON.
+func flattenJSON(record map[string]interface{}, maxDepth int, cells []jsonCell) []jsonCell {
+	for k, v := range record {
+		cells = flattenValue(k, v, 1, maxDepth, cells)
+	}
+	return cells
+}
+
+func flattenValue(path string, value interface{}, depth, maxDepth int, cells []jsonCell) []jsonCell {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if depth >= maxDepth || len(v) == 0 {
+			return append(cells, jsonCell{path, jsonText(v), profiler.KindObject})
+		}
+		for k, child := range v {
+			cells = flattenValue(path+"."+k, child, depth+1, maxDepth, cells)
+		}
+		return cells
+	case []interface{}:
+		cells = append(cells, jsonCell{arrayLengthPath(path), strconv.Itoa(len(v)), profiler.KindNumber})
+		if depth >= maxDepth {
+			return append(cells, jsonCell{path, jsonText(v), profiler.KindArray})
+		}
+		for _, item := range v {
+			cells = flattenValue(path+"[]", item, depth+1, maxDepth, cells)
+		}
+		return cells
+	case json.Number:
+		return append(cells, jsonCell{path, v.String(), profiler.KindNumber})
+	case string:
+		return append(cells, jsonCell{path, v, profiler.KindString})
+	case bool:
+		return append(cells, jsonCell{path, strconv.FormatBool(v), profiler.KindBool})
+	case nil:
+		return append(cells, jsonCell{path, "", profiler.KindNull})
+	default:
+		return append(cells, jsonCell{path, fmt.Sprintf("%v", v), profiler.KindUnknown})
+	}
+}
+
+func arrayLengthPath(path string) string {
+	return "len(" + path + ")"
+}
+
+func jsonText(v interface{}) string {
+	text, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(text)
+}
+
+// compareJSONPaths ordena os caminhos alfabeticamente, com len(items) logo
+// antes de items[].
+func compareJSONPaths(a, b string) int {
+	return cmp.Or(cmp.Compare(jsonPathKey(a), jsonPathKey(b)), cmp.Compare(b, a))
+}
+
+func jsonPathKey(path string) string {
+	if strings.HasPrefix(path, "len(") && strings.HasSuffix(path, ")") {
+		return path[len("len(") : len(path)-1]
+	}
+	return path
+}
@@ -0,0 +1,206 @@
+package infra
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/JGustavoCN/dataprofiler/internal/profiler"
+)
+
+func TestFlattenJSON(t *testing.T) {
+	const doc = `{
+		"id": 7,
+		"address": {"cep": "01310-100", "geo": {"lat": -23.5}},
+		"items": [{"ncm": "22030000", "tags": ["a", "b"]}, {"ncm": "84713012", "tags": []}],
+		"obs": null,
+		"extra": {}
+	}`
+
+	tests := []struct {
+		name     string
+		maxDepth int
+		expected []jsonCell
+	}{
+		{
+			name:     "Sem limite",
+			maxDepth: 10,
+			expected: []jsonCell{
+				{"address.cep", "01310-100", profiler.KindString},
+				{"address.geo.lat", "-23.5", profiler.KindNumber},
+				{"extra", "{}", profiler.KindObject},
+				{"id", "7", profiler.KindNumber},
+				{"items[].ncm", "22030000", profiler.KindString},
+				{"items[].ncm", "84713012", profiler.KindString},
+				{"items[].tags[]", "a", profiler.KindString},
+				{"items[].tags[]", "b", profiler.KindString},
+				{"len(items)", "2", profiler.KindNumber},
+				{"len(items[].tags)", "2", profiler.KindNumber},
+				{"len(items[].tags)", "0", profiler.KindNumber},
+				{"obs", "", profiler.KindNull},
+			},
+		},
+		{
+			name:     "Profundidade 1",
+			maxDepth: 1,
+			expected: []jsonCell{
+				{"address", `{"cep":"01310-100","geo":{"lat":-23.5}}`, profiler.KindObject},
+				{"extra", "{}", profiler.KindObject},
+				{"id", "7", profiler.KindNumber},
+				{"items", `[{"ncm":"22030000","tags":["a","b"]},{"ncm":"84713012","tags":[]}]`, profiler.KindArray},
+				{"len(items)", "2", profiler.KindNumber},
+				{"obs", "", profiler.KindNull},
+			},
+		},
+		{
+			// Os elementos de items estão no nível 2.
+			name:     "Profundidade 2",
+			maxDepth: 2,
+			expected: []jsonCell{
+				{"address.cep", "01310-100", profiler.KindString},
+				{"address.geo", `{"lat":-23.5}`, profiler.KindObject},
+				{"extra", "{}", profiler.KindObject},
+				{"id", "7", profiler.KindNumber},
+				{"items[]", `{"ncm":"22030000","tags":["a","b"]}`, profiler.KindObject},
+				{"items[]", `{"ncm":"84713012","tags":[]}`, profiler.KindObject},
+				{"len(items)", "2", profiler.KindNumber},
+				{"obs", "", profiler.KindNull},
+			},
+		},
+		{
+			name:     "Profundidade 3",
+			maxDepth: 3,
+			expected: []jsonCell{
+				{"address.cep", "01310-100", profiler.KindString},
+				{"address.geo.lat", "-23.5", profiler.KindNumber},
+				{"extra", "{}", profiler.KindObject},
+				{"id", "7", profiler.KindNumber},
+				{"items[].ncm", "22030000", profiler.KindString},
+				{"items[].ncm", "84713012", profiler.KindString},
+				{"items[].tags", `["a","b"]`, profiler.KindArray},
+				{"items[].tags", `[]`, profiler.KindArray},
+				{"len(items)", "2", profiler.KindNumber},
+				{"len(items[].tags)", "2", profiler.KindNumber},
+				{"len(items[].tags)", "0", profiler.KindNumber},
+				{"obs", "", profiler.KindNull},
+			},
+		},
+	}
+
+	record, err := decodeJSONLine([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenJSON(record, tt.maxDepth, nil)
+			// A ordem entre caminhos segue a do map; dentro de um array, a
+			// dos elementos.
+			slices.SortStableFunc(got, func(a, b jsonCell) int { return strings.Compare(a.path, b.path) })
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("flattenJSON:\n got %v\nwant %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDataAsync_NestedJSONL(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	jsonContent := `{"pedido": 1, "cliente": {"cep": "01310-100"}, "itens": [{"ncm": "22030000"}, {"ncm": "84713012"}]}
+{"pedido": 2, "cliente": {"cep": "20040-002"}, "itens": [], "meta": {}}
+`
+
+	headers, dataChan, err := ParseDataAsync(context.Background(), logger, strings.NewReader(jsonContent))
+	if err != nil {
+		t.Fatalf("Erro ao iniciar parser: %v", err)
+	}
+
+	expected := []string{"cliente.cep", "len(itens)", "itens[].ncm", "meta", "pedido"}
+	if !slices.Equal(headers, expected) {
+		t.Fatalf("Esperava %v, recebeu %v", expected, headers)
+	}
+
+	result := profiler.ProfileAsync(logger, headers, dataChan, "pedidos.jsonl")
+	columns := make(map[string]profiler.ColumnResult)
+	for _, column := range result.Columns {
+		columns[column.Name] = column
+	}
+
+	ncm := columns["itens[].ncm"]
+	if ncm.CountFilled != 2 || ncm.MissingCount != 1 || ncm.PresenceRatio != 0.5 {
+		t.Errorf("itens[].ncm: preenchidos=%d ausentes=%d presença=%v", ncm.CountFilled, ncm.MissingCount, ncm.PresenceRatio)
+	}
+	if ncm.MainType != profiler.TypeNCM {
+		t.Errorf("itens[].ncm deveria ser NCM pelo cabeçalho, foi %s", ncm.MainType)
+	}
+	// O array vazio não é chave ausente: len(itens) está em todos os registros.
+	if length := columns["len(itens)"]; length.MainType != profiler.TypeInteger || length.Stats[profiler.StatMax] != "2.00" || length.MissingCount != 0 {
+		t.Errorf("len(itens): tipo %s, stats %v, ausentes %d", length.MainType, length.Stats, length.MissingCount)
+	}
+	if meta := columns["meta"]; meta.CountFilled != 1 || meta.MissingCount != 1 || meta.JSONTypes["object"] != 1 {
+		t.Errorf("meta: preenchidos=%d ausentes=%d tipos=%v", meta.CountFilled, meta.MissingCount, meta.JSONTypes)
+	}
+	if cep := columns["cliente.cep"]; cep.MainType != profiler.TypeCEP || cep.PresenceRatio != 1 {
+		t.Errorf("cliente.cep: tipo %s, presença %v", cep.MainType, cep.PresenceRatio)
+	}
+}
+
+func TestParseDataAsync_NativeJSONTypes(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	jsonContent := `{"id": 9007199254740993, "valor": 1e+06, "taxa": 1.5e-07, "ativo": true, "obs": null, "codigo": 123}
+{"id": 9007199254740995, "valor": 2.5e+06, "taxa": 2.5e-07, "ativo": false, "codigo": "124"}
+{"id": 9007199254740997, "valor": 3e+06, "taxa": 1.5e-07, "ativo": true, "obs": "x", "codigo": 125}
+{"id": 9007199254740999, "valor": 4e+06, "taxa": 3.5e-07, "ativo": false, "obs": null, "codigo": 126}
+`
+
+	headers, dataChan, err := ParseDataAsync(context.Background(), logger, strings.NewReader(jsonContent))
+	if err != nil {
+		t.Fatalf("Erro ao iniciar parser: %v", err)
+	}
+
+	result := profiler.ProfileAsync(logger, headers, dataChan, "eventos.jsonl")
+	columns := make(map[string]profiler.ColumnResult)
+	for _, column := range result.Columns {
+		columns[column.Name] = column
+	}
+
+	for _, name := range []string{"valor", "taxa"} {
+		if column := columns[name]; column.MainType != profiler.TypeFloat || column.ConsistencyRatio != 1 {
+			t.Errorf("%s: esperava FLOAT consistente, recebeu %s (%v)", name, column.MainType, column.ConsistencyRatio)
+		}
+	}
+	if maxValor := columns["valor"].Stats[profiler.StatMax]; maxValor != "4000000.00" {
+		t.Errorf("valor: máximo %s", maxValor)
+	}
+
+	// O literal do inteiro acima de 2^53 chega intacto.
+	idIndex := slices.Index(headers, "id")
+	var ids []string
+	for _, row := range result.SampleRows {
+		ids = append(ids, row[idIndex])
+	}
+	if !slices.Contains(ids, "9007199254740993") {
+		t.Errorf("Inteiro acima de 2^53 perdeu precisão: %v", ids)
+	}
+
+	if ativo := columns["ativo"]; ativo.MainType != profiler.TypeBoolean {
+		t.Errorf("ativo: esperava BOOLEAN, recebeu %s", ativo.MainType)
+	}
+	if obs := columns["obs"]; obs.NullCount != 2 || obs.MissingCount != 1 || obs.CountFilled != 1 {
+		t.Errorf("obs: null=%d ausentes=%d preenchidos=%d", obs.NullCount, obs.MissingCount, obs.CountFilled)
+	}
+
+	codigo := columns["codigo"]
+	if codigo.MainType != profiler.TypeInteger || codigo.ConsistencyRatio != 0.75 {
+		t.Errorf("codigo: esperava INTEGER com consistência 0.75, recebeu %s (%v)", codigo.MainType, codigo.ConsistencyRatio)
+	}
+	if codigo.JSONTypes["string"] != 1 || codigo.JSONTypes["number"] != 3 {
+		t.Errorf("codigo: tipos JSON %v", codigo.JSONTypes)
+	}
+}
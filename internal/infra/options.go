@@ -0,0 +1,43 @@
+package infra
+
+// Linhas iniciais de um JSONL lidas para descobrir o schema quando
+// WithJSONLSchemaLines não é usado.
+const defaultJSONLSchemaLines = 1000
+
+// Níveis de aninhamento achatados em colunas quando WithJSONMaxDepth não é
+// usado.
+const defaultJSONMaxDepth = 10
+
+type parseConfig struct {
+	jsonlSchemaLines int
+	jsonMaxDepth     int
+}
+
+// ParseOption configura a leitura (ParseDataAsync e ParseFileAsync).
+type ParseOption func(*parseConfig)
+
+// WithJSONLSchemaLines define quantas linhas iniciais do JSONL são lidas
+// antes do streaming para montar o schema (a união das chaves). Campos que
+// só aparecem depois dessas linhas são ignorados e registrados no log.
+func WithJSONLSchemaLines(n int) ParseOption {
+	return func(c *parseConfig) {
+		c.jsonlSchemaLines = max(n, 1)
+	}
+}
+
+// WithJSONMaxDepth limita quantos níveis de objetos e arrays aninhados viram
+// colunas próprias (address.cep tem 2 níveis; items[].ncm, 3). Objetos e
+// arrays mais profundos são perfilados como texto JSON.
+func WithJSONMaxDepth(n int) ParseOption {
+	return func(c *parseConfig) {
+		c.jsonMaxDepth = max(n, 1)
+	}
+}
+
+func newParseConfig(opts []ParseOption) *parseConfig {
+	cfg := &parseConfig{jsonlSchemaLines: defaultJSONLSchemaLines, jsonMaxDepth: defaultJSONMaxDepth}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
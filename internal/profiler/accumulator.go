@@ -3,115 +3,295 @@ package profiler
 import (
 	"fmt"
 	"math/rand/v2"
-	"strconv"
 	"strings"
 	"time"
 )
 
 type ColumnAccumulator struct {
-	Name          string
-	TotalCount    int
-	BlankCount    int
-	CountFilled   int
-	TypeCounts    map[DataType]int
-	numericMin    *float64
-	numericMax    *float64
-	numericSum    float64
-	numericCount  int
-	numericSample []float64
-	sampleSize    int
-	rng           *rand.Rand
+	Name            string
+	TotalCount      int
+	BlankCount      int
+	MissingCount    int
+	NullCount       int
+	repeatedCount   int
+	CountFilled     int
+	NullTokenCounts map[string]int
+	nativeCounts    map[ValueKind]int
+	TypeCounts      map[DataType]int
+	validCounts     map[DataType]int
+	invalidCounts   map[DataType]int
+	formatCounts    map[DataType]map[string]int
+	fiscalKeys      *fiscalKeyTally
+	gtins           *gtinTally
+	dates           dateTally
+	temporal        map[DataType]*temporalTally
+	decimals        decimalTally
+	pendingNumbers  []pendingNumber
+	numeric         *numericSummary
+	distinct        *hyperLogLog
+	frequent        *spaceSaving
+	shapes          *spaceSaving
+	text            *textTally
+	examples        *exampleSampler
+	inferrer        *ColumnInferrer
+	numericSample   []float64
+	extremes        extremeTally
+	sampleSize      int
+	rng             *rand.Rand
+	cfg             *Config
 }
 
-func NewColumnAccumulator(name string) *ColumnAccumulator {
+func NewColumnAccumulator(name string, opts ...Option) *ColumnAccumulator {
 	seed := uint64(time.Now().UnixNano())
+	cfg := newConfig(opts)
+	rng := rand.New(rand.NewPCG(seed, seed+1))
 	return &ColumnAccumulator{
-		Name:          name,
-		TypeCounts:    make(map[DataType]int),
-		numericSample: make([]float64, 0, 1000),
-		sampleSize:    1000,
-		rng:           rand.New(rand.NewPCG(seed, seed+1)),
+		Name:            name,
+		NullTokenCounts: make(map[string]int),
+		nativeCounts:    make(map[ValueKind]int),
+		TypeCounts:      make(map[DataType]int),
+		validCounts:     make(map[DataType]int),
+		invalidCounts:   make(map[DataType]int),
+		formatCounts:    make(map[DataType]map[string]int),
+		temporal:        make(map[DataType]*temporalTally),
+		numeric:         newNumericSummary(),
+		distinct:        newHyperLogLog(cfg.hllPrecision),
+		frequent:        newSpaceSaving(cfg.topK * topKCapacityFactor),
+		shapes:          newSpaceSaving(cfg.topPatterns * topKCapacityFactor),
+		text:            newTextTally(),
+		examples:        newExampleSampler(rng),
+		inferrer:        defaultRegistry.ForColumn(name),
+		numericSample:   make([]float64, 0, 1000),
+		sampleSize:      1000,
+		rng:             rng,
+		cfg:             cfg,
 	}
 }
 
+// AddMissing registra um registro em que o campo não existe (JSONL). Conta
+// também como vazio.
+func (acc *ColumnAccumulator) AddMissing() {
+	acc.TotalCount++
+	acc.BlankCount++
+	acc.MissingCount++
+}
+
+// AddRepeated é AddTyped para valores adicionais do mesmo registro
+// (elementos de array no JSONL): contam como valores, mas não como novos
+// registros na presença do campo.
+func (acc *ColumnAccumulator) AddRepeated(value string, kind ValueKind, line int) {
+	acc.repeatedCount++
+	acc.AddTyped(value, kind, line)
+}
+
 func (acc *ColumnAccumulator) Add(value string) {
+	acc.AddAt(value, 0)
+}
+
+// AddAt é Add com a linha do arquivo de origem, citada nos exemplos de
+// valores inválidos.
+func (acc *ColumnAccumulator) AddAt(value string, line int) {
+	acc.AddTyped(value, KindUnknown, line)
+}
+
+// AddTyped é AddAt com o tipo nativo do valor JSON. Números JSON não passam
+// pela convenção decimal, booleanos são BOOLEAN direto e null conta como
+// vazio em NullCount. Valores do tipo principal com tipo JSON diferente do
+// majoritário ("123" entre números) reduzem a consistência.
+func (acc *ColumnAccumulator) AddTyped(value string, kind ValueKind, line int) {
 	acc.TotalCount++
+	if kind == KindNull {
+		acc.BlankCount++
+		acc.NullCount++
+		return
+	}
 
 	trimmedValue := strings.TrimSpace(value)
 	if trimmedValue == "" {
 		acc.BlankCount++
 		return
 	}
+	if acc.cfg.isNullToken(trimmedValue) {
+		acc.BlankCount++
+		acc.NullTokenCounts[trimmedValue]++
+		return
+	}
 
 	acc.CountFilled++
+	if kind != KindUnknown {
+		acc.nativeCounts[kind]++
+	}
+	acc.text.add(value)
+	acc.distinct.add(trimmedValue)
+	acc.frequent.add(trimmedValue)
+	acc.shapes.addWithExample(ValueShape(trimmedValue), trimmedValue)
 
-	inferredType := InferType(trimmedValue, acc.Name)
+	var inferredType DataType
+	if kind == KindBool {
+		inferredType = TypeBoolean
+	} else {
+		inferredType = acc.inferrer.Infer(trimmedValue)
+	}
+	if kind == KindNumber && (isNumericType(inferredType) || inferredType == TypeString) {
+		acc.addJSONNumber(trimmedValue, line)
+		return
+	}
+	if isNumericType(inferredType) {
+		acc.addNumber(trimmedValue, kind, line)
+		return
+	}
 	acc.TypeCounts[inferredType]++
 
-	if inferredType == TypeInteger || inferredType == TypeFloat {
-		valClean := strings.Replace(trimmedValue, ",", ".", 1)
+	example := exampleValid
+	if checked, valid := acc.cfg.validate(inferredType, trimmedValue); checked {
+		if valid {
+			acc.validCounts[inferredType]++
+		} else {
+			acc.invalidCounts[inferredType]++
+			example = exampleInvalid
+		}
+	}
 
-		val, err := strconv.ParseFloat(valClean, 64)
-		if err == nil {
-			acc.updateNumericStats(val)
+	if format := classifyFormat(inferredType, trimmedValue); format != "" {
+		if acc.formatCounts[inferredType] == nil {
+			acc.formatCounts[inferredType] = make(map[string]int)
 		}
+		acc.formatCounts[inferredType][format]++
+	}
+
+	if inferredType == TypeFiscalKey44 {
+		if acc.fiscalKeys == nil {
+			acc.fiscalKeys = newFiscalKeyTally()
+		}
+		acc.fiscalKeys.add(trimmedValue)
+	}
+
+	if inferredType == TypeEAN {
+		if acc.gtins == nil {
+			acc.gtins = newGTINTally()
+		}
+		acc.gtins.add(trimmedValue)
+	}
+
+	if inferredType == TypeDate {
+		example = acc.dates.add(trimmedValue)
+	}
+	acc.examples.add(exampleKey{inferredType, example, kind}, trimmedValue, line)
+
+	if isTemporal(inferredType) {
+		if acc.temporal[inferredType] == nil {
+			acc.temporal[inferredType] = newTemporalTally()
+		}
+		acc.temporal[inferredType].add(inferredType, trimmedValue)
 	}
 }
 
-func (acc *ColumnAccumulator) updateNumericStats(val float64) {
-	acc.numericCount++
-	acc.numericSum += val
+// addNumber interpreta o valor conforme a convenção decimal da coluna. Valores
+// ambíguos ("1.234") ficam pendentes até Result, que os resolve pela
+// convenção da coluna inteira, inclusive a de acumuladores mesclados; só os
+// que passam de pendingNumbersLimit usam a convenção conhecida até ali.
+func (acc *ColumnAccumulator) addNumber(value string, native ValueKind, line int) {
+	number, _ := scanNumber(value, acc.decimals.separator())
+	acc.decimals.add(number.evidence)
 
-	if acc.numericMin == nil || val < *acc.numericMin {
-		v := val
-		acc.numericMin = &v
+	if number.ambiguous && len(acc.pendingNumbers) < pendingNumbersLimit {
+		acc.pendingNumbers = append(acc.pendingNumbers, pendingNumber{value: value, native: native, line: line})
+		return
 	}
 
-	if acc.numericMax == nil || val > *acc.numericMax {
-		v := val
-		acc.numericMax = &v
+	acc.TypeCounts[number.kind]++
+	acc.examples.add(exampleKey{number.kind, exampleValid, native}, value, line)
+	acc.updateNumericStats(number.value, line)
+}
+
+func (acc *ColumnAccumulator) flushPendingNumbers() {
+	separator := acc.decimals.separator()
+	for _, pending := range acc.pendingNumbers {
+		number, _ := scanNumber(pending.value, separator)
+		acc.TypeCounts[number.kind]++
+		acc.examples.add(exampleKey{number.kind, exampleValid, pending.native}, pending.value, pending.line)
+		acc.updateNumericStats(number.value, pending.line)
 	}
+	acc.pendingNumbers = nil
+}
+
+func (acc *ColumnAccumulator) updateNumericStats(val float64, line int) {
+	acc.numeric.add(val)
+	acc.extremes.add(val, line)
 
 	if len(acc.numericSample) < acc.sampleSize {
 		acc.numericSample = append(acc.numericSample, val)
 	} else {
-		randomIndex := acc.rng.IntN(acc.numericCount)
+		randomIndex := acc.rng.IntN(acc.numeric.count)
 		if randomIndex < acc.sampleSize {
 			acc.numericSample[randomIndex] = val
 		}
 	}
 }
 
+// Result consolida a coluna. Números ambíguos pendentes são resolvidos com a
+// convenção decimal de todos os valores vistos (pt-BR por padrão).
 func (acc *ColumnAccumulator) Result() ColumnResult {
-	mainType := acc.determineMainType()
+	acc.flushPendingNumbers()
+	mainType := determineMainType(acc.TypeCounts)
 	sensitivity, reasonSensitivity := ClassifySensitivity(mainType)
-	stats := make(map[StatKey]string)
+	var stats map[StatKey]string
 	var histogram map[string]int
-	if mainType == TypeInteger || mainType == TypeFloat {
-		if acc.numericCount > 0 && acc.numericMin != nil && acc.numericMax != nil {
-			stats[StatMin] = strconv.FormatFloat(*acc.numericMin, 'f', 2, 64)
-			stats[StatMax] = strconv.FormatFloat(*acc.numericMax, 'f', 2, 64)
-			stats[StatSum] = strconv.FormatFloat(acc.numericSum, 'f', 2, 64)
-
-			avg := acc.numericSum / float64(acc.numericCount)
-			stats[StatAverage] = strconv.FormatFloat(avg, 'f', 2, 64)
+	var outliers *OutlierProfile
+	validCount, invalidCount := acc.validCounts[mainType], acc.invalidCounts[mainType]
+	var fiscalKeyProfile *FiscalKeyProfile
+	var gtinProfile *GTINProfile
+	var dateOrder string
+	switch mainType {
+	case TypeFiscalKey44:
+		fiscalKeyProfile = acc.fiscalKeys.profile()
+	case TypeEAN:
+		gtinProfile = acc.gtins.profile()
+	case TypeDate:
+		dateOrder = acc.dates.order()
+		invalidCount = acc.dates.invalid()
+		validCount = acc.TypeCounts[TypeDate] - invalidCount
+	}
+	var decimalSeparator string
+	if isNumericType(mainType) {
+		if acc.decimals.decided() {
+			decimalSeparator = acc.decimals.separator().String()
+		}
+		if acc.numeric.count > 0 {
+			stats = acc.numeric.stats()
 			histogram = calculateHistogram(acc.numericSample)
+			// Enquanto a amostra comporta todos os valores, a contagem é exata.
+			exact := len(acc.numericSample) == acc.numeric.count
+			outliers = acc.extremes.profile(acc.numeric, acc.numericSample, exact)
 		}
 	}
 
-	var filledRatio, blankRatio float64
+	var filledRatio, blankRatio, presenceRatio float64
 	if acc.TotalCount > 0 {
 		filledRatio = float64(acc.CountFilled) / float64(acc.TotalCount)
 		blankRatio = float64(acc.BlankCount) / float64(acc.TotalCount)
+		records := acc.TotalCount - acc.repeatedCount
+		presenceRatio = float64(records-acc.MissingCount) / float64(records)
 	}
 
+	nativeMajority := majorityKind(acc.nativeCounts)
 	consistencyRatio := 1.0
 	if acc.CountFilled > 0 {
-		winnerCount := acc.TypeCounts[mainType]
+		winnerCount := acc.TypeCounts[mainType] - invalidCount - acc.examples.nativeMismatches(mainType, nativeMajority)
 		consistencyRatio = float64(winnerCount) / float64(acc.CountFilled)
 	}
 
+	distinct, uniqueness, candidateKey := distinctMetrics(acc.distinct, acc.CountFilled, acc.BlankCount)
+	var topValues []ValueCount
+	if !candidateKey {
+		topValues = acc.frequent.top(acc.cfg.topK)
+	}
+
+	patterns := topPatterns(acc.shapes, acc.cfg.topPatterns)
 	sla, reasonSLA := CalculateSLA(blankRatio, consistencyRatio, mainType)
+	mismatch := acc.examples.mismatchPattern(mainType, nativeMajority, &acc.dates)
+	reasonSLA = explainSLA(sla, reasonSLA, blankRatio, consistencyRatio, mainType, patterns, mismatch, acc.CountFilled)
+	sla, reasonSLA = applyOutlierSLA(acc.cfg, sla, reasonSLA, outliers)
 	return ColumnResult{
 		Name:              acc.Name,
 		MainType:          mainType,
@@ -119,51 +299,39 @@ func (acc *ColumnAccumulator) Result() ColumnResult {
 		SensitivityReason: reasonSensitivity,
 		CountFilled:       acc.CountFilled,
 		BlankCount:        acc.BlankCount,
+		MissingCount:      acc.MissingCount,
+		NullCount:         acc.NullCount,
+		JSONTypes:         jsonTypeCounts(acc.nativeCounts),
+		NullTokenCounts:   acc.NullTokenCounts,
 		TypeCounts:        acc.TypeCounts,
+		DistinctEstimate:  distinct,
+		UniquenessRatio:   uniqueness,
+		IsCandidateKey:    candidateKey,
+		CardinalityClass:  classifyCardinality(mainType, distinct, acc.CountFilled),
+		TopValues:         topValues,
+		TopPatterns:       patterns,
+		TextProfile:       acc.text.profile(),
+		InvalidExamples:   acc.examples.examples(mainType, nativeMajority, &acc.dates),
+		ValidCount:        validCount,
+		InvalidCount:      invalidCount,
+		FormatCounts:      acc.formatCounts[mainType],
+		FiscalKeyProfile:  fiscalKeyProfile,
+		GTINProfile:       gtinProfile,
+		DateOrder:         dateOrder,
+		TemporalProfile:   acc.temporal[mainType].profile(mainType),
+		DecimalSeparator:  decimalSeparator,
 		Filled:            filledRatio,
 		BlankRatio:        blankRatio,
+		PresenceRatio:     presenceRatio,
 		SLA:               sla,
 		SlaReason:         reasonSLA,
 		ConsistencyRatio:  consistencyRatio,
 		Stats:             stats,
 		Histogram:         histogram,
+		Outliers:          outliers,
 	}
 }
 
-func (acc *ColumnAccumulator) determineMainType() DataType {
-	var winner DataType = TypeString
-	maxCount := 0
-
-	priority := map[DataType]int{
-		TypeEmpty:       0,
-		TypeString:      1,
-		TypeBoolean:     2,
-		TypeInteger:     3,
-		TypeFloat:       4,
-		TypeDate:        5,
-		TypeDateCompact: 6,
-		TypeEmail:       7,
-		TypePlaca:       8,
-		TypeCEP:         9,
-		TypeCPF:         10,
-		TypeCNPJ:        11,
-		TypeFiscalKey44: 12,
-	}
-
-	for dtype, count := range acc.TypeCounts {
-		if count > maxCount {
-			maxCount = count
-			winner = dtype
-		} else if count == maxCount {
-			if priority[dtype] > priority[winner] {
-				winner = dtype
-			}
-		}
-	}
-
-	return winner
-}
-
 func calculateHistogram(values []float64) map[string]int {
 	if len(values) == 0 {
 		return nil
@@ -30,6 +30,28 @@ func TestAccumulator(t *testing.T) {
 		}
 	})
 
+	t.Run("Chassi (VIN) com dígito verificador", func(t *testing.T) {
+		acc := NewColumnAccumulator("chassi")
+		for range 198 {
+			acc.Add("1M8GDM9AXKP042788")
+		}
+		acc.Add("1M8GDM9A1KP042788")
+		acc.Add("")
+
+		result := acc.Result()
+
+		if result.MainType != TypeVIN || result.Sensitivity != SensitivityInternal {
+			t.Fatalf("Esperava VIN INTERNAL, recebeu %s %s", result.MainType, result.Sensitivity)
+		}
+		if result.ValidCount != 198 || result.InvalidCount != 1 {
+			t.Errorf("Esperava 198 válidos e 1 inválido, recebeu %d e %d", result.ValidCount, result.InvalidCount)
+		}
+		// Severidade alta: 0,5% de vazios é alerta, não crítico.
+		if result.SLA != SlaWarning {
+			t.Errorf("Esperava WARNING, recebeu %s (%s)", result.SLA, result.SlaReason)
+		}
+	})
+
 	t.Run("Deve calcular estatísticas de números positivos", func(t *testing.T) {
 		acc := NewColumnAccumulator("Precos")
 
@@ -198,8 +220,8 @@ func TestAccumulator(t *testing.T) {
 	t.Run("Integração SLA: Alta Severidade (CPF) com Inconsistência", func(t *testing.T) {
 		acc := NewColumnAccumulator("Documentos")
 
-		acc.Add("123.456.789-00")
-		acc.Add("111.222.333-44")
+		acc.Add("123.456.789-09")
+		acc.Add("111.222.333-96")
 		acc.Add("Não Informado")
 
 		result := acc.Result()
@@ -226,7 +248,7 @@ func TestAccumulator(t *testing.T) {
 		}
 
 		acc.Add("erro")
-		acc.Add("n/a")
+		acc.Add("dez")
 
 		result := acc.Result()
 
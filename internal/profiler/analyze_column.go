@@ -1,10 +1,5 @@
 package profiler
 
-import (
-	"strconv"
-	"strings"
-)
-
 type Column struct {
 	Name   string
 	Values []string
@@ -18,108 +13,67 @@ type ColumnResult struct {
 	SLA               QualityScore       `json:"sla"`
 	SlaReason         string             `json:"sla_reason"`
 	BlankCount        int                `json:"blank_count"`
+	NullTokenCounts   map[string]int     `json:"null_token_counts,omitempty"` // marcadores de nulo (NULL, N/A, #N/D...) contados em BlankCount
 	CountFilled       int                `json:"count_filled"`
 	Filled            float64            `json:"filled_ratio"`
 	BlankRatio        float64            `json:"blank_ratio"`
+	MissingCount      int                `json:"missing_count,omitempty"` // registros sem o campo (JSONL), contados em BlankCount
+	NullCount         int                `json:"null_count,omitempty"`    // null explícito (JSONL), contado em BlankCount
+	JSONTypes         map[string]int     `json:"json_types,omitempty"`    // valores preenchidos por tipo nativo JSON
+	PresenceRatio     float64            `json:"presence_ratio"`          // registros que trazem o campo / total de registros
 	ConsistencyRatio  float64            `json:"consistency_ratio"`
 	TypeCounts        map[DataType]int   `json:"type_counts"`
+	DistinctEstimate  int                `json:"distinct_estimate"`           // HyperLogLog; exato até 1024 distintos
+	UniquenessRatio   float64            `json:"uniqueness_ratio"`            // distintos / preenchidos
+	IsCandidateKey    bool               `json:"is_candidate_key"`            // sem vazios e sem repetições
+	CardinalityClass  string             `json:"cardinality_class,omitempty"` // ENUM ou CATEGORICAL
+	TopValues         []ValueCount       `json:"top_values,omitempty"`
+	TopPatterns       []PatternCount     `json:"top_patterns,omitempty"`
+	TextProfile       *TextProfile       `json:"text_profile,omitempty"`
+	ValidCount        int                `json:"valid_count,omitempty"`   // bem formados que passaram na validação (dígito verificador, calendário)
+	InvalidCount      int                `json:"invalid_count,omitempty"` // bem formados que falharam na validação
+	InvalidExamples   []InvalidExample   `json:"invalid_examples,omitempty"`
+	FormatCounts      map[string]int     `json:"format_counts,omitempty"` // variantes de leiaute (ex.: CNPJ numérico vs alfanumérico)
+	FiscalKeyProfile  *FiscalKeyProfile  `json:"fiscal_key_profile,omitempty"`
+	GTINProfile       *GTINProfile       `json:"gtin_profile,omitempty"`
+	DateOrder         string             `json:"date_order,omitempty"` // DD/MM, MM/DD, AMBIGUOUS ou MIXED
+	TemporalProfile   *TemporalProfile   `json:"temporal_profile,omitempty"`
+	DecimalSeparator  string             `json:"decimal_separator,omitempty"` // convenção numérica detectada ("," ou ".")
 	Stats             map[StatKey]string `json:"stats,omitempty"`
 	Histogram         map[string]int     `json:"histogram,omitempty"`
+	Outliers          *OutlierProfile    `json:"outliers,omitempty"`
 }
 
-func AnalyzeColumn(column Column) (result ColumnResult) {
-
+// AnalyzeColumn perfila uma coluna já carregada em memória pelo mesmo
+// caminho do streaming (ColumnAccumulator). Como a coluna inteira está
+// disponível, a amostra numérica é a coluna toda: histograma e contagem de
+// outliers ficam exatos.
+func AnalyzeColumn(column Column, opts ...Option) ColumnResult {
 	if len(column.Values) == 0 {
 		return ColumnResult{Name: column.Name, MainType: TypeEmpty}
 	}
 
-	result.TypeCounts = make(map[DataType]int)
-	var numericValues []float64
-
-	filledCount := 0
-	blankCount := 0
-
-	for _, v := range column.Values {
-		trimmed := strings.TrimSpace(v)
-
-		if trimmed == "" {
-			blankCount++
-			continue
-		}
-
-		inferredType := InferType(trimmed, column.Name)
-		result.TypeCounts[inferredType]++
-		filledCount++
-
-		if inferredType == TypeInteger || inferredType == TypeFloat {
-
-			valClean := strings.Replace(trimmed, ",", ".", 1)
-
-			if number, err := strconv.ParseFloat(valClean, 64); err == nil {
-				numericValues = append(numericValues, number)
-			}
-		}
+	acc := NewColumnAccumulator(column.Name, opts...)
+	acc.sampleSize = max(acc.sampleSize, len(column.Values))
+	for i, v := range column.Values {
+		// Linha no CSV de origem: o cabeçalho ocupa a linha 1.
+		acc.AddAt(v, i+2)
 	}
-
-	result.MainType = determineMainType(result.TypeCounts)
-	result.Sensitivity, result.SensitivityReason = ClassifySensitivity(result.MainType)
-
-	if result.MainType == TypeInteger || result.MainType == TypeFloat {
-		result.Stats = StatsCalc(numericValues)
-		result.Histogram = calculateHistogram(numericValues)
-	}
-
-	result.Name = column.Name
-	result.BlankCount = blankCount
-	result.CountFilled = filledCount
-
-	total := float64(len(column.Values))
-	if total > 0 {
-		result.Filled = float64(filledCount) / total
-		result.BlankRatio = float64(blankCount) / total
-
-		result.ConsistencyRatio = 1.0
-		if filledCount > 0 {
-			winnerCount := result.TypeCounts[result.MainType]
-			result.ConsistencyRatio = float64(winnerCount) / float64(filledCount)
-		}
-		result.SLA, result.SlaReason = CalculateSLA(result.BlankRatio, result.ConsistencyRatio, result.MainType)
-
-	} else {
-		result.SLA = SlaGood
-		result.ConsistencyRatio = 1.0
-	}
-
-	return result
+	return acc.Result()
 }
 
+// determineMainType escolhe o tipo mais frequente; empates ficam com o tipo
+// de maior prioridade no registro.
 func determineMainType(counts map[DataType]int) DataType {
 	var winner DataType = TypeString
 	maxCount := 0
 
-	priority := map[DataType]int{
-		TypeEmpty:       0,
-		TypeString:      1,
-		TypeBoolean:     2,
-		TypeInteger:     3,
-		TypeFloat:       4,
-		TypeDate:        5,
-		TypeDateCompact: 6,
-		TypeEmail:       7,
-		TypePlaca:       8,
-		TypeCEP:         9,
-		TypeCPF:         10,
-		TypeCNPJ:        11,
-		TypeFiscalKey44: 12,
-	}
-
 	for dtype, count := range counts {
 		if count > maxCount {
 			maxCount = count
 			winner = dtype
 		} else if count == maxCount {
-
-			if priority[dtype] > priority[winner] {
+			if defaultRegistry.Priority(dtype) > defaultRegistry.Priority(winner) {
 				winner = dtype
 			}
 		}
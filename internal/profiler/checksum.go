@@ -0,0 +1,302 @@
+package profiler
+
+// validateChecksum retorna checked=false quando o tipo não possui validação.
+func validateChecksum(t DataType, value string) (checked bool, valid bool) {
+	return defaultRegistry.validateChecksum(t, value)
+}
+
+// IsValidCPF confere os dois dígitos verificadores (módulo 11) de um CPF,
+// com ou sem máscara. Sequências repetidas (111.111.111-11) são rejeitadas.
+func IsValidCPF(value string) bool {
+	digits := onlyDigits(value)
+	if len(digits) != 11 || allSame(digits) {
+		return false
+	}
+
+	for pos := 9; pos <= 10; pos++ {
+		sum := 0
+		for i := 0; i < pos; i++ {
+			sum += digits[i] * (pos + 1 - i)
+		}
+		if digits[pos] != mod11Digit(sum) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidCNPJ confere os dois dígitos verificadores (módulo 11) de um CNPJ,
+// com ou sem máscara. Aceita também o CNPJ alfanumérico (a partir de 2026),
+// em que cada caractere da base vale seu código ASCII menos 48.
+func IsValidCNPJ(value string) bool {
+	chars := cnpjValues(value)
+	if len(chars) != 14 || allSame(chars) {
+		return false
+	}
+	if chars[12] > 9 || chars[13] > 9 {
+		return false
+	}
+
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for pos := 12; pos <= 13; pos++ {
+		sum := 0
+		offset := 13 - pos
+		for i := 0; i < pos; i++ {
+			sum += chars[i] * weights[i+offset]
+		}
+		if chars[pos] != mod11Digit(sum) {
+			return false
+		}
+	}
+	return true
+}
+
+func mod11Digit(sum int) int {
+	rest := sum % 11
+	if rest < 2 {
+		return 0
+	}
+	return 11 - rest
+}
+
+func onlyDigits(value string) []int {
+	digits := make([]int, 0, len(value))
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			digits = append(digits, int(c-'0'))
+		}
+	}
+	return digits
+}
+
+// cnpjValues converte dígitos e letras maiúsculas no valor ASCII-48 usado
+// pelo cálculo do CNPJ. Caracteres de máscara são ignorados; qualquer outro
+// caractere invalida o valor.
+func cnpjValues(value string) []int {
+	values := make([]int, 0, len(value))
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= '0' && c <= '9', c >= 'A' && c <= 'Z':
+			values = append(values, int(c)-48)
+		case c == '.' || c == '/' || c == '-':
+		default:
+			return nil
+		}
+	}
+	return values
+}
+
+func allSame(digits []int) bool {
+	for _, d := range digits[1:] {
+		if d != digits[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidPIS confere o dígito verificador de PIS/PASEP/NIS/NIT (11 dígitos,
+// com ou sem máscara 000.00000.00-0).
+func IsValidPIS(value string) bool {
+	digits := onlyDigits(value)
+	if len(digits) != 11 || allSame(digits) {
+		return false
+	}
+
+	weights := []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	sum := 0
+	for i, w := range weights {
+		sum += digits[i] * w
+	}
+	dv := 11 - sum%11
+	if dv >= 10 {
+		dv = 0
+	}
+	return digits[10] == dv
+}
+
+// IsValidRENAVAM confere o dígito verificador do RENAVAM. Códigos antigos de
+// 9 dígitos são completados com zeros à esquerda.
+func IsValidRENAVAM(value string) bool {
+	digits := onlyDigits(value)
+	if len(digits) == 9 {
+		digits = append([]int{0, 0}, digits...)
+	}
+	if len(digits) != 11 || allSame(digits) {
+		return false
+	}
+
+	weights := []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	sum := 0
+	for i, w := range weights {
+		sum += digits[i] * w
+	}
+	dv := sum * 10 % 11
+	if dv == 10 {
+		dv = 0
+	}
+	return digits[10] == dv
+}
+
+// IsValidCNH confere os dois dígitos verificadores do número de registro da
+// CNH (11 dígitos), pelo algoritmo do DENATRAN: quando o primeiro resulta em
+// 10 (vira 0), o segundo sofre o desconto de 2, exceto se o seu resto também
+// for 10 (vira 0). Restos 0 e 1 com desconto não geram dígito válido.
+func IsValidCNH(value string) bool {
+	digits := onlyDigits(value)
+	if len(digits) != 11 || allSame(digits) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += digits[i] * (9 - i)
+	}
+	dv1, discount := sum%11, 0
+	if dv1 >= 10 {
+		dv1, discount = 0, 2
+	}
+
+	sum = 0
+	for i := 0; i < 9; i++ {
+		sum += digits[i] * (i + 1)
+	}
+	dv2 := sum % 11
+	if dv2 >= 10 {
+		dv2 = 0
+	} else {
+		dv2 -= discount
+	}
+	return digits[9] == dv1 && digits[10] == dv2
+}
+
+// IsValidTituloEleitor confere o título de eleitor (12 dígitos): sequencial,
+// código da UF (01 a 28) e dois dígitos verificadores. Em SP (01) e MG (02),
+// resto zero gera dígito 1.
+func IsValidTituloEleitor(value string) bool {
+	digits := onlyDigits(value)
+	if len(digits) != 12 || allSame(digits) {
+		return false
+	}
+
+	uf := digits[8]*10 + digits[9]
+	if uf < 1 || uf > 28 {
+		return false
+	}
+	titleDigit := func(sum int) int {
+		rest := sum % 11
+		switch {
+		case rest == 0 && uf <= 2:
+			return 1
+		case rest == 10:
+			return 0
+		}
+		return rest
+	}
+
+	sum := 0
+	for i := 0; i < 8; i++ {
+		sum += digits[i] * (i + 2)
+	}
+	dv1 := titleDigit(sum)
+	dv2 := titleDigit(digits[8]*7 + digits[9]*8 + dv1*9)
+	return digits[10] == dv1 && digits[11] == dv2
+}
+
+// IsValidCNS confere o Cartão Nacional de Saúde (15 dígitos). Cartões
+// definitivos começam com 1 ou 2 e provisórios com 7, 8 ou 9; em ambos a soma
+// ponderada (pesos 15 a 1) deve ser múltipla de 11.
+func IsValidCNS(value string) bool {
+	digits := onlyDigits(value)
+	if len(digits) != 15 {
+		return false
+	}
+	switch digits[0] {
+	case 1, 2, 7, 8, 9:
+	default:
+		return false
+	}
+
+	sum := 0
+	for i, d := range digits {
+		sum += d * (15 - i)
+	}
+	return sum%11 == 0
+}
+
+var vinWeights = []int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// IsValidVIN confere o dígito verificador do chassi (ISO 3779, posição 9):
+// letras são transliteradas, a soma ponderada é reduzida módulo 11 e o resto
+// 10 é representado por "X".
+func IsValidVIN(value string) bool {
+	if len(value) != 17 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < len(value); i++ {
+		v, ok := vinValue(value[i])
+		if !ok {
+			return false
+		}
+		sum += v * vinWeights[i]
+	}
+
+	expected := byte('0' + sum%11)
+	if sum%11 == 10 {
+		expected = 'X'
+	}
+	return value[8] == expected
+}
+
+func vinValue(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'A' && c <= 'H':
+		return int(c-'A') + 1, true
+	case c >= 'J' && c <= 'N':
+		return int(c-'J') + 1, true
+	case c == 'P':
+		return 7, true
+	case c == 'R':
+		return 9, true
+	case c >= 'S' && c <= 'Z':
+		return int(c-'S') + 2, true
+	}
+	return 0, false
+}
+
+// IsValidContainer confere o dígito verificador ISO 6346 do contêiner: as
+// letras valem de 10 a 38 pulando múltiplos de 11, cada posição é ponderada
+// por 2^i e o resto módulo 11 (10 vira 0) deve ser o último dígito.
+func IsValidContainer(value string) bool {
+	if !matchContainer(value) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 10; i++ {
+		c := value[i]
+		v := int(c - '0')
+		if c >= 'A' && c <= 'Z' {
+			v = containerLetterValue(c)
+		}
+		sum += v << i
+	}
+	return int(value[10]-'0') == sum%11%10
+}
+
+func containerLetterValue(c byte) int {
+	v := 10
+	for l := byte('A'); l < c; l++ {
+		v++
+		if v%11 == 0 {
+			v++
+		}
+	}
+	return v
+}
@@ -0,0 +1,141 @@
+package profiler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChecksumValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator func(string) bool
+		value     string
+		expected  bool
+	}{
+		{"CPF Válido Formatado", IsValidCPF, "529.982.247-25", true},
+		{"CPF Válido Limpo", IsValidCPF, "12345678909", true},
+		{"CPF Dígito Errado", IsValidCPF, "123.456.789-00", false},
+		{"CPF Sequência Repetida", IsValidCPF, "111.111.111-11", false},
+		{"CPF Tamanho Errado", IsValidCPF, "1234567890", false},
+
+		{"CNPJ Válido Formatado", IsValidCNPJ, "11.222.333/0001-81", true},
+		{"CNPJ Válido Limpo", IsValidCNPJ, "12345678000195", true},
+		{"CNPJ Dígito Errado", IsValidCNPJ, "12.345.678/0001-90", false},
+		{"CNPJ Sequência Repetida", IsValidCNPJ, "00.000.000/0000-00", false},
+		{"CNPJ Alfanumérico Formatado", IsValidCNPJ, "12.ABC.345/01DE-35", true},
+		{"CNPJ Alfanumérico Limpo", IsValidCNPJ, "12ABC34501DE35", true},
+		{"CNPJ Alfanumérico Dígito Errado", IsValidCNPJ, "12ABC34501DE53", false},
+		{"CNPJ Alfanumérico com Letra no DV", IsValidCNPJ, "12ABC34501DE3A", false},
+		{"CNPJ Minúsculo", IsValidCNPJ, "12abc34501de35", false},
+
+		{"PIS Válido Formatado", IsValidPIS, "120.30203.09-4", true},
+		{"PIS Dígito Errado", IsValidPIS, "12030203095", false},
+		{"RENAVAM Válido", IsValidRENAVAM, "00639798349", true},
+		{"RENAVAM Antigo 9 Dígitos", IsValidRENAVAM, "639798349", true},
+		{"RENAVAM Dígito Errado", IsValidRENAVAM, "00639798340", false},
+		{"CNH Válida", IsValidCNH, "12345678900", true},
+		{"CNH Dígito Errado", IsValidCNH, "12345678901", false},
+		{"CNH com Desconto no 2º Dígito", IsValidCNH, "10003959500", true},
+		{"CNH com Desconto e Resto 10", IsValidCNH, "10010294700", true},
+		{"CNH com Desconto Aplicado ao Resto 10", IsValidCNH, "10010294708", false},
+		{"CNH com Desconto Negativo", IsValidCNH, "10019005600", false},
+		{"CNH com Desconto Negativo (resto 0)", IsValidCNH, "98765432109", false},
+		{"Título de Eleitor Válido (SP)", IsValidTituloEleitor, "1023 0000 0132", true},
+		{"Título de Eleitor Válido", IsValidTituloEleitor, "123456781996", true},
+		{"Título de Eleitor UF Inexistente", IsValidTituloEleitor, "123456783096", false},
+		{"Título de Eleitor Dígito Errado", IsValidTituloEleitor, "123456781997", false},
+		{"CNS Definitivo", IsValidCNS, "170 1234 5678 0008", true},
+		{"CNS Provisório", IsValidCNS, "710000000031676", true},
+		{"CNS Dígito Errado", IsValidCNS, "170123456780009", false},
+		{"CNS Prefixo Inválido", IsValidCNS, "370123456780008", false},
+
+		{"Contêiner Válido", IsValidContainer, "CSQU3054383", true},
+		{"Contêiner Válido (Resto 10)", IsValidContainer, "MSCU1000070", true},
+		{"Contêiner Dígito Errado", IsValidContainer, "MSKU1234567", false},
+		{"Contêiner Minúsculo", IsValidContainer, "csqu3054383", false},
+
+		{"VIN Válido", IsValidVIN, "1M8GDM9AXKP042788", true},
+		{"VIN Dígito Errado", IsValidVIN, "1M8GDM9A1KP042788", false},
+		{"VIN com Letra Proibida", IsValidVIN, "1M8GDM9AXKP04278O", false},
+		{"VIN Tamanho Errado", IsValidVIN, "1M8GDM9AXKP04278", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validator(tt.value); got != tt.expected {
+				t.Errorf("validação de %q = %v; esperava %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChecksumAffectsConsistency(t *testing.T) {
+	acc := NewColumnAccumulator("cpf_cliente")
+	acc.Add("529.982.247-25")
+	acc.Add("123.456.789-09")
+	acc.Add("111.111.111-11")
+	acc.Add("123.456.789-00")
+
+	result := acc.Result()
+
+	if result.MainType != TypeCPF {
+		t.Fatalf("Esperava MainType CPF, recebeu %s", result.MainType)
+	}
+	if result.ValidCount != 2 || result.InvalidCount != 2 {
+		t.Errorf("Esperava 2 válidos e 2 inválidos, recebeu %d/%d", result.ValidCount, result.InvalidCount)
+	}
+	if result.ConsistencyRatio != 0.5 {
+		t.Errorf("Dígitos inválidos deveriam contar como inconsistência, consistência %f", result.ConsistencyRatio)
+	}
+	if result.SLA != SlaCritical {
+		t.Errorf("Esperava SLA CRITICAL, recebeu %s", result.SLA)
+	}
+
+	sync := AnalyzeColumn(Column{
+		Name:   "cpf_cliente",
+		Values: []string{"529.982.247-25", "123.456.789-09", "111.111.111-11", "123.456.789-00"},
+	})
+	if sync.ValidCount != result.ValidCount || sync.InvalidCount != result.InvalidCount {
+		t.Errorf("AnalyzeColumn divergiu do acumulador: %d/%d", sync.ValidCount, sync.InvalidCount)
+	}
+}
+
+func TestCNPJFormatCounts(t *testing.T) {
+	acc := NewColumnAccumulator("cnpj_fornecedor")
+	acc.Add("11.222.333/0001-81")
+	acc.Add("12345678000195")
+	acc.Add("12.ABC.345/01DE-35")
+
+	result := acc.Result()
+
+	if result.MainType != TypeCNPJ {
+		t.Fatalf("Esperava MainType CNPJ, recebeu %s", result.MainType)
+	}
+	if result.FormatCounts[FormatCNPJNumeric] != 2 {
+		t.Errorf("Esperava 2 CNPJs numéricos, recebeu %d", result.FormatCounts[FormatCNPJNumeric])
+	}
+	if result.FormatCounts[FormatCNPJAlphanumeric] != 1 {
+		t.Errorf("Esperava 1 CNPJ alfanumérico, recebeu %d", result.FormatCounts[FormatCNPJAlphanumeric])
+	}
+	if result.InvalidCount != 0 {
+		t.Errorf("Nenhum CNPJ deveria ser inválido, recebeu %d", result.InvalidCount)
+	}
+}
+
+func TestDocumentTypesClassification(t *testing.T) {
+	for _, dtype := range []DataType{TypePIS, TypeCNH, TypeTituloEleitor, TypeCNS} {
+		if sens, _ := ClassifySensitivity(dtype); sens != SensitivityConfidential {
+			t.Errorf("%s deveria ser CONFIDENTIAL, recebeu %s", dtype, sens)
+		}
+		if getSeverity(dtype) != SeverityHigh {
+			t.Errorf("%s deveria ter severidade HIGH", dtype)
+		}
+	}
+
+	if _, reason := ClassifySensitivity(TypeCNS); !strings.Contains(reason, "Saúde") {
+		t.Errorf("CNS deveria ser classificado como dado de saúde, recebeu %q", reason)
+	}
+	if sens, _ := ClassifySensitivity(TypeRENAVAM); sens != SensitivityInternal {
+		t.Errorf("RENAVAM deveria ser INTERNAL, recebeu %s", sens)
+	}
+}
@@ -0,0 +1,111 @@
+package profiler
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	DateOrderDayMonth  = "DD/MM"
+	DateOrderMonthDay  = "MM/DD"
+	DateOrderAmbiguous = "AMBIGUOUS"
+	DateOrderMixed     = "MIXED"
+)
+
+// dateTally acompanha a validade de calendário das datas de uma coluna e,
+// para datas com barra, quais leituras (DD/MM ou MM/DD) são possíveis.
+type dateTally struct {
+	dayMonthOnly int
+	monthDayOnly int
+	bothOrders   int
+	impossible   int
+}
+
+// add retorna a categoria da data, usada na amostragem de exemplos inválidos.
+func (dt *dateTally) add(value string) exampleKind {
+	if matchDateIso(value) {
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			dt.impossible++
+			return exampleImpossibleDate
+		}
+		return exampleValid
+	}
+
+	if !matchDateBr(value) {
+		return exampleValid
+	}
+
+	first, _ := strconv.Atoi(value[0:2])
+	second, _ := strconv.Atoi(value[3:5])
+	year, _ := strconv.Atoi(value[6:10])
+
+	asDayMonth := isCalendarDate(year, second, first)
+	asMonthDay := isCalendarDate(year, first, second)
+
+	switch {
+	case asDayMonth && asMonthDay:
+		dt.bothOrders++
+		return exampleValid
+	case asDayMonth:
+		dt.dayMonthOnly++
+		return exampleDayMonthDate
+	case asMonthDay:
+		dt.monthDayOnly++
+		return exampleMonthDayDate
+	default:
+		dt.impossible++
+		return exampleImpossibleDate
+	}
+}
+
+func (dt *dateTally) merge(other dateTally) {
+	dt.dayMonthOnly += other.dayMonthOnly
+	dt.monthDayOnly += other.monthDayOnly
+	dt.bothOrders += other.bothOrders
+	dt.impossible += other.impossible
+}
+
+// order retorna a convenção das datas com barra da coluna, ou "" se não há
+// nenhuma data com barra.
+func (dt *dateTally) order() string {
+	switch {
+	case dt.dayMonthOnly > 0 && dt.monthDayOnly > 0:
+		return DateOrderMixed
+	case dt.dayMonthOnly > 0:
+		return DateOrderDayMonth
+	case dt.monthDayOnly > 0:
+		return DateOrderMonthDay
+	case dt.bothOrders > 0:
+		return DateOrderAmbiguous
+	}
+	return ""
+}
+
+// invalid soma as datas impossíveis e, em colunas MIXED, as da convenção
+// minoritária. Só há contradição quando as duas convenções aparecem.
+func (dt *dateTally) invalid() int {
+	return dt.impossible + min(dt.dayMonthOnly, dt.monthDayOnly)
+}
+
+// contradicts indica se as datas da categoria entram em invalid: só DD/MM
+// ou só MM/DD contra a convenção predominante (em MIXED, a minoria; no
+// empate, as MM/DD).
+func (dt *dateTally) contradicts(kind exampleKind) bool {
+	switch kind {
+	case exampleImpossibleDate:
+		return true
+	case exampleDayMonthDate:
+		return dt.monthDayOnly > dt.dayMonthOnly
+	case exampleMonthDayDate:
+		return dt.dayMonthOnly >= dt.monthDayOnly
+	}
+	return false
+}
+
+func isCalendarDate(year, month, day int) bool {
+	if month < 1 || month > 12 || day < 1 {
+		return false
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	return t.Day() == day && t.Month() == time.Month(month)
+}
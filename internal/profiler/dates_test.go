@@ -0,0 +1,64 @@
+package profiler
+
+import "testing"
+
+func TestDateOrderDetection(t *testing.T) {
+	tests := []struct {
+		name            string
+		values          []string
+		expectedOrder   string
+		expectedInvalid int
+	}{
+		{"Coluna BR", []string{"25/12/2023", "01/02/2024", "13/05/2024"}, DateOrderDayMonth, 0},
+		{"Coluna US", []string{"12/25/2023", "01/02/2024", "05/13/2024"}, DateOrderMonthDay, 0},
+		{"Coluna Ambígua", []string{"01/02/2024", "11/12/2023", "05/05/2024"}, DateOrderAmbiguous, 0},
+		{"Coluna Misturada", []string{"25/12/2023", "26/12/2023", "12/27/2023"}, DateOrderMixed, 1},
+		{"Datas Impossíveis", []string{"31/02/2024", "2024-13-45", "2024-02-29", "15/01/2024"}, DateOrderDayMonth, 2},
+		{"Ano Não Bissexto", []string{"29/02/2023", "28/02/2023"}, DateOrderDayMonth, 1},
+		{"Somente ISO", []string{"2024-01-01", "2024-02-30"}, "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := NewColumnAccumulator("data")
+			for _, v := range tt.values {
+				acc.Add(v)
+			}
+			result := acc.Result()
+
+			if result.MainType != TypeDate {
+				t.Fatalf("Esperava MainType DATE, recebeu %s", result.MainType)
+			}
+			if result.DateOrder != tt.expectedOrder {
+				t.Errorf("DateOrder: esperava %q, recebeu %q", tt.expectedOrder, result.DateOrder)
+			}
+			if result.InvalidCount != tt.expectedInvalid {
+				t.Errorf("InvalidCount: esperava %d, recebeu %d", tt.expectedInvalid, result.InvalidCount)
+			}
+
+			sync := AnalyzeColumn(Column{Name: "data", Values: tt.values})
+			if sync.DateOrder != result.DateOrder || sync.InvalidCount != result.InvalidCount {
+				t.Errorf("AnalyzeColumn divergiu: %q/%d", sync.DateOrder, sync.InvalidCount)
+			}
+		})
+	}
+}
+
+func TestImpossibleDatesAffectSLA(t *testing.T) {
+	values := make([]string, 0, 100)
+	for i := 0; i < 90; i++ {
+		values = append(values, "15/03/2024")
+	}
+	for i := 0; i < 10; i++ {
+		values = append(values, "31/02/2024")
+	}
+
+	result := AnalyzeColumn(Column{Name: "dt_emissao", Values: values})
+
+	if result.ConsistencyRatio != 0.9 {
+		t.Errorf("Esperava consistência 0.9, recebeu %f", result.ConsistencyRatio)
+	}
+	if result.SLA != SlaWarning {
+		t.Errorf("Datas impossíveis deveriam degradar o SLA para WARNING, recebeu %s", result.SLA)
+	}
+}
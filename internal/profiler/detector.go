@@ -0,0 +1,347 @@
+package profiler
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TypeDetector reconhece um tipo de dado. Os detectores são avaliados em
+// ordem decrescente de prioridade e o primeiro que reconhece o valor define
+// o tipo; a mesma prioridade desempata o tipo principal da coluna.
+type TypeDetector interface {
+	Type() DataType
+	Priority() int
+	// HeaderHints lista palavras-chave do cabeçalho (em minúsculas). Quando
+	// não vazia, o detector só vale para colunas cujo nome contém alguma delas.
+	HeaderHints() []string
+	Match(value string) bool
+	Sensitivity() (DataSensitivity, string)
+	Severity() SeverityLevel
+}
+
+// ChecksumDetector é implementado por detectores cujos valores bem formados
+// ainda passam por uma validação, como o dígito verificador.
+type ChecksumDetector interface {
+	Checksum(value string) bool
+}
+
+// DetectorSpec descreve um detector declarativo, registrado em Go via
+// NewPatternDetector ou carregado de arquivo YAML/JSON.
+type DetectorSpec struct {
+	Type              DataType        `json:"type" yaml:"type"`
+	Pattern           string          `json:"pattern" yaml:"pattern"`
+	HeaderHints       []string        `json:"header_hints" yaml:"header_hints"`
+	Priority          int             `json:"priority" yaml:"priority"`
+	Sensitivity       DataSensitivity `json:"sensitivity" yaml:"sensitivity"`
+	SensitivityReason string          `json:"sensitivity_reason" yaml:"sensitivity_reason"`
+	Severity          string          `json:"severity" yaml:"severity"` // HIGH, MEDIUM ou LOW
+}
+
+type detectorFile struct {
+	Detectors []DetectorSpec `json:"detectors" yaml:"detectors"`
+}
+
+type patternDetector struct {
+	dtype       DataType
+	pattern     *regexp.Regexp
+	scan        func(string) bool // varredura equivalente a pattern, se houver
+	validate    func(string) bool
+	hints       []string
+	priority    int
+	sensitivity DataSensitivity
+	reason      string
+	severity    SeverityLevel
+}
+
+func (d *patternDetector) Type() DataType          { return d.dtype }
+func (d *patternDetector) Priority() int           { return d.priority }
+func (d *patternDetector) HeaderHints() []string   { return d.hints }
+func (d *patternDetector) Severity() SeverityLevel { return d.severity }
+
+func (d *patternDetector) Sensitivity() (DataSensitivity, string) {
+	return d.sensitivity, d.reason
+}
+
+func (d *patternDetector) Match(value string) bool {
+	switch {
+	case d.scan != nil:
+		if !d.scan(value) {
+			return false
+		}
+	case d.pattern != nil:
+		if !d.pattern.MatchString(value) {
+			return false
+		}
+	}
+	return d.validate == nil || d.validate(value)
+}
+
+// checksumDetector acrescenta a validação de dígito verificador a um
+// patternDetector.
+type checksumDetector struct {
+	*patternDetector
+	checksum func(string) bool
+}
+
+func (d *checksumDetector) Checksum(value string) bool {
+	return d.checksum(value)
+}
+
+// NewPatternDetector compila um DetectorSpec em um TypeDetector.
+func NewPatternDetector(spec DetectorSpec) (TypeDetector, error) {
+	if spec.Type == "" {
+		return nil, fmt.Errorf("detector sem tipo")
+	}
+	if spec.Pattern == "" {
+		return nil, fmt.Errorf("detector %s sem pattern", spec.Type)
+	}
+	if spec.Priority <= defaultPriority(TypeString) {
+		return nil, fmt.Errorf("detector %s: prioridade %d deve ser maior que a de STRING (%d)", spec.Type, spec.Priority, defaultPriority(TypeString))
+	}
+
+	pattern, err := regexp.Compile(spec.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("detector %s: pattern inválido: %w", spec.Type, err)
+	}
+
+	sensitivity := spec.Sensitivity
+	switch sensitivity {
+	case "":
+		sensitivity = SensitivityPublic
+	case SensitivityPublic, SensitivityInternal, SensitivityConfidential:
+	default:
+		return nil, fmt.Errorf("detector %s: sensibilidade desconhecida %q", spec.Type, spec.Sensitivity)
+	}
+
+	severity, err := parseSeverity(spec.Severity)
+	if err != nil {
+		return nil, fmt.Errorf("detector %s: %w", spec.Type, err)
+	}
+
+	hints := make([]string, 0, len(spec.HeaderHints))
+	for _, h := range spec.HeaderHints {
+		if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+			hints = append(hints, h)
+		}
+	}
+
+	reason := spec.SensitivityReason
+	if reason == "" {
+		reason = "Detector customizado"
+	}
+
+	return &patternDetector{
+		dtype:       spec.Type,
+		pattern:     pattern,
+		hints:       hints,
+		priority:    spec.Priority,
+		sensitivity: sensitivity,
+		reason:      reason,
+		severity:    severity,
+	}, nil
+}
+
+func parseSeverity(s string) (SeverityLevel, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "HIGH":
+		return SeverityHigh, nil
+	case "MEDIUM":
+		return SeverityMedium, nil
+	case "LOW", "":
+		return SeverityLow, nil
+	}
+	return 0, fmt.Errorf("severidade desconhecida %q (use HIGH, MEDIUM ou LOW)", s)
+}
+
+// Registry mantém os detectores de tipo. Leituras não bloqueiam: cada
+// registro publica uma nova cópia imutável da lista.
+type Registry struct {
+	mu    sync.Mutex
+	state atomic.Pointer[registryState]
+}
+
+type registryState struct {
+	detectors []TypeDetector
+	byType    map[DataType]TypeDetector
+}
+
+// NewRegistry cria um registro com os detectores informados.
+func NewRegistry(detectors ...TypeDetector) *Registry {
+	r := &Registry{}
+	r.state.Store(&registryState{byType: map[DataType]TypeDetector{}})
+	for _, d := range detectors {
+		r.Register(d)
+	}
+	return r
+}
+
+// Register adiciona um detector. Para a mesma prioridade, detectores
+// registrados antes são avaliados primeiro.
+func (r *Registry) Register(d TypeDetector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.state.Load()
+	detectors := append(slices.Clone(current.detectors), d)
+	slices.SortStableFunc(detectors, func(a, b TypeDetector) int {
+		return cmp.Compare(b.Priority(), a.Priority())
+	})
+
+	byType := make(map[DataType]TypeDetector, len(detectors))
+	for _, det := range detectors {
+		if _, exists := byType[det.Type()]; !exists {
+			byType[det.Type()] = det
+		}
+	}
+
+	r.state.Store(&registryState{detectors: detectors, byType: byType})
+}
+
+// Infer retorna o tipo do primeiro detector que reconhece o valor. Para
+// classificar vários valores da mesma coluna, prefira ForColumn.
+func (r *Registry) Infer(value string, headerName string) DataType {
+	return r.ForColumn(headerName).Infer(value)
+}
+
+// ColumnInferrer classifica os valores de uma coluna com os detectores que
+// valem para o cabeçalho dela.
+type ColumnInferrer struct {
+	detectors []TypeDetector
+}
+
+// ForColumn confronta o cabeçalho com as dicas dos detectores uma única vez.
+// Detectores registrados depois não afetam o ColumnInferrer retornado.
+func (r *Registry) ForColumn(headerName string) *ColumnInferrer {
+	headerLower := strings.ToLower(headerName)
+	all := r.state.Load().detectors
+	detectors := make([]TypeDetector, 0, len(all))
+	for _, d := range all {
+		if hints := d.HeaderHints(); len(hints) > 0 && !containsAny(headerLower, hints...) {
+			continue
+		}
+		detectors = append(detectors, d)
+	}
+	return &ColumnInferrer{detectors: detectors}
+}
+
+// Infer retorna o tipo do primeiro detector da coluna que reconhece o valor.
+func (c *ColumnInferrer) Infer(value string) DataType {
+	if value == "" {
+		return TypeEmpty
+	}
+	for _, d := range c.detectors {
+		if d.Match(value) {
+			return d.Type()
+		}
+	}
+	return TypeString
+}
+
+// Priority retorna a prioridade do tipo (0 para tipos não registrados).
+func (r *Registry) Priority(t DataType) int {
+	if d, ok := r.state.Load().byType[t]; ok {
+		return d.Priority()
+	}
+	return defaultPriority(t)
+}
+
+// Sensitivity retorna a classificação LGPD declarada para o tipo.
+func (r *Registry) Sensitivity(t DataType) (DataSensitivity, string) {
+	if d, ok := r.state.Load().byType[t]; ok {
+		return d.Sensitivity()
+	}
+	return SensitivityPublic, "Dado Geral / Não Classificado"
+}
+
+// Severity retorna a severidade de SLA declarada para o tipo.
+func (r *Registry) Severity(t DataType) SeverityLevel {
+	if d, ok := r.state.Load().byType[t]; ok {
+		return d.Severity()
+	}
+	return SeverityLow
+}
+
+// validateChecksum retorna checked=false quando nenhum detector do tipo
+// declara validação de dígito verificador.
+func (r *Registry) validateChecksum(t DataType, value string) (checked bool, valid bool) {
+	d, ok := r.state.Load().byType[t]
+	if !ok {
+		return false, false
+	}
+	validator, ok := d.(ChecksumDetector)
+	if !ok {
+		return false, false
+	}
+	return true, validator.Checksum(value)
+}
+
+// LoadDetectors registra os detectores de um arquivo .yaml/.yml ou .json no
+// formato {"detectors": [DetectorSpec, ...]}.
+func (r *Registry) LoadDetectors(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var file detectorFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	default:
+		return fmt.Errorf("extensão de arquivo de detectores não suportada: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("erro lendo detectores de %s: %w", path, err)
+	}
+
+	detectors := make([]TypeDetector, 0, len(file.Detectors))
+	for _, spec := range file.Detectors {
+		d, err := NewPatternDetector(spec)
+		if err != nil {
+			return err
+		}
+		detectors = append(detectors, d)
+	}
+	for _, d := range detectors {
+		r.Register(d)
+	}
+	return nil
+}
+
+var defaultRegistry = NewRegistry(builtinDetectors()...)
+
+// DefaultRegistry retorna o registro usado por InferType e pelo profiling.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// RegisterDetector adiciona um detector customizado ao registro padrão.
+func RegisterDetector(d TypeDetector) {
+	defaultRegistry.Register(d)
+}
+
+// LoadDetectorsFile carrega detectores customizados no registro padrão.
+func LoadDetectorsFile(path string) error {
+	return defaultRegistry.LoadDetectors(path)
+}
+
+func defaultPriority(t DataType) int {
+	switch t {
+	case TypeString:
+		return 10
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,134 @@
+package profiler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const typeAgencia DataType = "AGENCIA"
+
+func TestRegistry_CustomDetector(t *testing.T) {
+	registry := NewRegistry(builtinDetectors()...)
+
+	detector, err := NewPatternDetector(DetectorSpec{
+		Type:        typeAgencia,
+		Pattern:     `^\d{4}-\d$`,
+		HeaderHints: []string{"Agencia", "agência"},
+		Priority:    175,
+		Sensitivity: SensitivityInternal,
+		Severity:    "high",
+	})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	registry.Register(detector)
+
+	if got := registry.Infer("1234-5", "AGENCIA_CLIENTE"); got != typeAgencia {
+		t.Errorf("Esperava %s, recebeu %s", typeAgencia, got)
+	}
+	if got := registry.Infer("1234-5", "observacao"); got != TypeString {
+		t.Errorf("Sem a dica no cabeçalho esperava STRING, recebeu %s", got)
+	}
+	if got := registry.Infer("123.456.789-09", "agencia"); got != TypeCPF {
+		t.Errorf("Detectores nativos devem continuar valendo, recebeu %s", got)
+	}
+
+	if sens, reason := registry.Sensitivity(typeAgencia); sens != SensitivityInternal || reason != "Detector customizado" {
+		t.Errorf("Sensibilidade inesperada: %s (%s)", sens, reason)
+	}
+	if got := registry.Severity(typeAgencia); got != SeverityHigh {
+		t.Errorf("Esperava severidade HIGH, recebeu %v", got)
+	}
+	if registry.Priority(typeAgencia) <= registry.Priority(TypeFiscalKey44) {
+		t.Errorf("Prioridade customizada deveria superar a da chave fiscal")
+	}
+
+	// O registro padrão não é afetado.
+	if got := InferType("1234-5", "agencia"); got != TypeString {
+		t.Errorf("Registro padrão alterado: recebeu %s", got)
+	}
+}
+
+func TestRegistry_LoadDetectors(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "YAML",
+			file: "detectores.yaml",
+			content: `detectors:
+  - type: AGENCIA
+    pattern: '^\d{4}-\d$'
+    header_hints: [agencia]
+    priority: 175
+    sensitivity: INTERNAL
+    sensitivity_reason: Dado bancário
+    severity: MEDIUM
+`,
+		},
+		{
+			name: "JSON",
+			file: "detectores.json",
+			content: `{"detectors": [{"type": "AGENCIA", "pattern": "^\\d{4}-\\d$", "header_hints": ["agencia"],
+				"priority": 175, "sensitivity": "INTERNAL", "sensitivity_reason": "Dado bancário", "severity": "MEDIUM"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			registry := NewRegistry(builtinDetectors()...)
+			if err := registry.LoadDetectors(path); err != nil {
+				t.Fatalf("Erro inesperado: %v", err)
+			}
+
+			if got := registry.Infer("0001-9", "agencia"); got != typeAgencia {
+				t.Errorf("Esperava %s, recebeu %s", typeAgencia, got)
+			}
+			if _, reason := registry.Sensitivity(typeAgencia); reason != "Dado bancário" {
+				t.Errorf("Motivo inesperado: %s", reason)
+			}
+			if got := registry.Severity(typeAgencia); got != SeverityMedium {
+				t.Errorf("Esperava severidade MEDIUM, recebeu %v", got)
+			}
+		})
+	}
+}
+
+func TestNewPatternDetector_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec DetectorSpec
+	}{
+		{"Sem tipo", DetectorSpec{Pattern: `^\d$`, Priority: 50}},
+		{"Sem pattern", DetectorSpec{Type: typeAgencia, Priority: 50}},
+		{"Regex inválida", DetectorSpec{Type: typeAgencia, Pattern: `^(\d$`, Priority: 50}},
+		{"Prioridade abaixo de STRING", DetectorSpec{Type: typeAgencia, Pattern: `^\d$`, Priority: 5}},
+		{"Sensibilidade desconhecida", DetectorSpec{Type: typeAgencia, Pattern: `^\d$`, Priority: 50, Sensitivity: "SECRET"}},
+		{"Severidade desconhecida", DetectorSpec{Type: typeAgencia, Pattern: `^\d$`, Priority: 50, Severity: "CRITICAL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPatternDetector(tt.spec); err == nil {
+				t.Errorf("Esperava erro para %+v", tt.spec)
+			}
+		})
+	}
+}
+
+func TestDetermineMainType_UsesRegistryPriority(t *testing.T) {
+	counts := map[DataType]int{TypeInteger: 3, TypeCPF: 3, TypeString: 3}
+	if got := determineMainType(counts); got != TypeCPF {
+		t.Errorf("Empate deveria favorecer CPF, recebeu %s", got)
+	}
+}
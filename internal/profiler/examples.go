@@ -0,0 +1,181 @@
+package profiler
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+// Quantidade de exemplos guardados por categoria de valor e reportados por
+// coluna em InvalidExamples.
+const invalidExamplesLimit = 20
+
+// Formatos guardados por categoria, para explicar o SLA pelo formato mais
+// comum entre os valores fora do padrão.
+const exampleShapesCapacity = topKCapacityFactor
+
+// InvalidExample é um valor fora do tipo principal da coluna (ou que falhou
+// na validação), com a linha do arquivo de origem (zero quando desconhecida).
+type InvalidExample struct {
+	Line   int    `json:"line,omitempty"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+type exampleKind uint8
+
+const (
+	exampleValid exampleKind = iota
+	exampleInvalid
+	exampleImpossibleDate
+	exampleDayMonthDate
+	exampleMonthDayDate
+)
+
+type exampleKey struct {
+	dtype  DataType
+	kind   exampleKind
+	native ValueKind
+}
+
+type exampleReservoir struct {
+	seen   int
+	items  []InvalidExample
+	shapes *spaceSaving
+}
+
+// exampleSampler guarda, para cada tipo inferido e resultado de validação,
+// uma amostra de reservatório dos valores. Como o tipo principal só é
+// conhecido no fim, todas as categorias são amostradas e a escolha dos
+// exemplos inválidos acontece em examples.
+type exampleSampler struct {
+	rng   *rand.Rand
+	byKey map[exampleKey]*exampleReservoir
+}
+
+func newExampleSampler(rng *rand.Rand) *exampleSampler {
+	return &exampleSampler{rng: rng, byKey: make(map[exampleKey]*exampleReservoir)}
+}
+
+func (s *exampleSampler) add(key exampleKey, value string, line int) {
+	r := s.byKey[key]
+	if r == nil {
+		r = &exampleReservoir{shapes: newSpaceSaving(exampleShapesCapacity)}
+		s.byKey[key] = r
+	}
+	r.seen++
+	r.shapes.addWithExample(ValueShape(value), value)
+
+	example := InvalidExample{Line: line, Value: value}
+	if len(r.items) < invalidExamplesLimit {
+		r.items = append(r.items, example)
+		return
+	}
+	if k := s.rng.IntN(r.seen); k < invalidExamplesLimit {
+		r.items[k] = example
+	}
+}
+
+func (s *exampleSampler) merge(other *exampleSampler) {
+	for key, o := range other.byKey {
+		r := s.byKey[key]
+		if r == nil {
+			shapes := newSpaceSaving(exampleShapesCapacity)
+			shapes.merge(o.shapes)
+			s.byKey[key] = &exampleReservoir{seen: o.seen, items: slices.Clone(o.items), shapes: shapes}
+			continue
+		}
+		r.items = mergeReservoirs(s.rng, r.items, r.seen, o.items, o.seen, invalidExamplesLimit)
+		r.seen += o.seen
+		r.shapes.merge(o.shapes)
+	}
+}
+
+// examples escolhe os exemplos fora do padrão para o tipo principal,
+// alternando entre as categorias (da mais frequente para a menos) para
+// mostrar cada tipo de problema, e os ordena pela linha.
+func (s *exampleSampler) examples(mainType DataType, majority ValueKind, dates *dateTally) []InvalidExample {
+	type candidate struct {
+		reason string
+		r      *exampleReservoir
+	}
+	var candidates []candidate
+	for key, r := range s.byKey {
+		if reason := exampleReason(key, mainType, majority, dates); reason != "" {
+			candidates = append(candidates, candidate{reason: reason, r: r})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if c := cmp.Compare(b.r.seen, a.r.seen); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.reason, b.reason)
+	})
+
+	var examples []InvalidExample
+	for i := 0; len(examples) < invalidExamplesLimit; i++ {
+		added := false
+		for _, c := range candidates {
+			if i < len(c.r.items) && len(examples) < invalidExamplesLimit {
+				example := c.r.items[i]
+				example.Reason = c.reason
+				examples = append(examples, example)
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	slices.SortFunc(examples, func(a, b InvalidExample) int {
+		return cmp.Compare(a.Line, b.Line)
+	})
+	return examples
+}
+
+// mismatchPattern retorna o formato mais comum entre os valores fora do
+// padrão do tipo principal (Count zero se não há nenhum).
+func (s *exampleSampler) mismatchPattern(mainType DataType, majority ValueKind, dates *dateTally) PatternCount {
+	shapes := newSpaceSaving(exampleShapesCapacity)
+	for key, r := range s.byKey {
+		if exampleReason(key, mainType, majority, dates) != "" {
+			shapes.merge(r.shapes)
+		}
+	}
+	top := topPatterns(shapes, 1)
+	if len(top) == 0 {
+		return PatternCount{}
+	}
+	return top[0]
+}
+
+// exampleReason retorna "" para valores conformes ao tipo principal.
+func exampleReason(key exampleKey, mainType DataType, majority ValueKind, dates *dateTally) string {
+	if key.dtype != mainType {
+		return fmt.Sprintf("%s em vez de %s", key.dtype, mainType)
+	}
+	if key.kind != exampleInvalid && isNativeMismatch(key.native, majority) {
+		return fmt.Sprintf("%s JSON em vez de %s", key.native, majority)
+	}
+
+	switch key.kind {
+	case exampleInvalid:
+		return fmt.Sprintf("%s inválido", mainType)
+	case exampleImpossibleDate:
+		return "Data inexistente no calendário"
+	case exampleDayMonthDate:
+		if dates.contradicts(key.kind) {
+			return "Data só válida como " + DateOrderDayMonth
+		}
+	case exampleMonthDayDate:
+		if dates.contradicts(key.kind) {
+			return "Data só válida como " + DateOrderMonthDay
+		}
+	}
+	return ""
+}
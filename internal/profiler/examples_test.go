@@ -0,0 +1,99 @@
+package profiler
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// analyzeBoth roda a coluna nos modos streaming (com a numeração de linhas
+// do CSV, cabeçalho na linha 1) e síncrono, com as mesmas opções.
+func analyzeBoth(name string, values []string, opts ...Option) map[string]ColumnResult {
+	acc := NewColumnAccumulator(name, opts...)
+	for i, v := range values {
+		acc.AddAt(v, i+2)
+	}
+	return map[string]ColumnResult{
+		"streaming": acc.Result(),
+		"sync":      AnalyzeColumn(Column{Name: name, Values: values}, opts...),
+	}
+}
+
+func TestInvalidExamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		values   []string
+		expected []InvalidExample
+	}{
+		{
+			name:   "CPF com dígito errado e texto",
+			header: "cpf",
+			values: []string{"529.982.247-25", "123.456.789-09", "123.456.789-00", "", "sem cpf", "529.982.247-25"},
+			expected: []InvalidExample{
+				{Line: 4, Value: "123.456.789-00", Reason: "CPF inválido"},
+				{Line: 6, Value: "sem cpf", Reason: "STRING em vez de CPF"},
+			},
+		},
+		{
+			name:   "Datas DD/MM com data americana e inexistente",
+			header: "data_emissao",
+			values: []string{"31/01/2024", "25/12/2024", "12/31/2024", "30/02/2024", "05/06/2024"},
+			expected: []InvalidExample{
+				{Line: 4, Value: "12/31/2024", Reason: "Data só válida como MM/DD"},
+				{Line: 5, Value: "30/02/2024", Reason: "Data inexistente no calendário"},
+			},
+		},
+		{
+			name:     "Coluna consistente",
+			header:   "quantidade",
+			values:   []string{"1", "2", "3", "NULL"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for mode, result := range analyzeBoth(tt.header, tt.values) {
+				if !slices.Equal(result.InvalidExamples, tt.expected) {
+					t.Errorf("[%s] InvalidExamples = %+v; esperava %+v", mode, result.InvalidExamples, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidExamples_Bounded(t *testing.T) {
+	var values []string
+	for i := range 1000 {
+		values = append(values, fmt.Sprint(i))
+	}
+	for i := range 200 {
+		values = append(values, fmt.Sprintf("lixo %d", i))
+	}
+	values = append(values, "1,5", "2,5")
+
+	for mode, result := range analyzeBoth("quantidade", values) {
+		examples := result.InvalidExamples
+		if len(examples) != invalidExamplesLimit {
+			t.Fatalf("[%s] esperava %d exemplos, recebeu %d", mode, invalidExamplesLimit, len(examples))
+		}
+		if !slices.IsSortedFunc(examples, func(a, b InvalidExample) int { return a.Line - b.Line }) {
+			t.Errorf("[%s] exemplos deveriam vir ordenados pela linha", mode)
+		}
+
+		// A categoria rara aparece inteira mesmo com muitos textos.
+		floats := 0
+		for _, e := range examples {
+			if e.Value != values[e.Line-2] {
+				t.Errorf("[%s] linha %d deveria conter %q, exemplo traz %q", mode, e.Line, values[e.Line-2], e.Value)
+			}
+			if e.Reason == "FLOAT em vez de INTEGER" {
+				floats++
+			}
+		}
+		if floats != 2 {
+			t.Errorf("[%s] esperava os 2 FLOAT entre os exemplos, recebeu %d", mode, floats)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package profiler
+
+// IsValidCFOP confere a estrutura do CFOP (4 dígitos, com ou sem ponto): o
+// primeiro dígito indica entrada (1 a 3) ou saída (5 a 7) e o segundo, o
+// grupo da operação (1 a 9).
+func IsValidCFOP(value string) bool {
+	digits := onlyDigits(value)
+	if len(digits) != 4 {
+		return false
+	}
+	switch digits[0] {
+	case 1, 2, 3, 5, 6, 7:
+	default:
+		return false
+	}
+	return digits[1] != 0
+}
+
+var (
+	icmsCSTs    = codeSet("00", "10", "20", "30", "40", "41", "50", "51", "60", "70", "90")
+	federalCSTs = codeSet( // PIS/COFINS e IPI
+		"01", "02", "03", "04", "05", "06", "07", "08", "09",
+		"49", "50", "51", "52", "53", "54", "55", "56",
+		"60", "61", "62", "63", "64", "65", "66", "67",
+		"70", "71", "72", "73", "74", "75", "98", "99",
+	)
+	csosnCodes = codeSet("101", "102", "103", "201", "202", "203", "300", "400", "500", "900")
+)
+
+func codeSet(codes ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(codes))
+	for _, c := range codes {
+		set[c] = struct{}{}
+	}
+	return set
+}
+
+// IsValidCST aceita o CST de ICMS com ou sem o dígito de origem (0 a 8) e os
+// CSTs de PIS/COFINS e IPI.
+func IsValidCST(value string) bool {
+	if !isDigits(value) {
+		return false
+	}
+	switch len(value) {
+	case 2:
+		_, icms := icmsCSTs[value]
+		_, federal := federalCSTs[value]
+		return icms || federal
+	case 3:
+		_, icms := icmsCSTs[value[1:]]
+		return value[0] <= '8' && icms
+	}
+	return false
+}
+
+// IsValidCSOSN confere o código do Simples Nacional, com ou sem o dígito de
+// origem da mercadoria.
+func IsValidCSOSN(value string) bool {
+	if !isDigits(value) {
+		return false
+	}
+	if len(value) == 4 {
+		if value[0] > '8' {
+			return false
+		}
+		value = value[1:]
+	}
+	_, ok := csosnCodes[value]
+	return ok
+}
+
+// IsValidCEST confere a estrutura do CEST (SS.III.DD): segmento de 01 a 28,
+// conforme o Convênio ICMS 142/2018.
+func IsValidCEST(value string) bool {
+	digits := onlyDigits(value)
+	if len(digits) != 7 {
+		return false
+	}
+	segment := digits[0]*10 + digits[1]
+	return segment >= 1 && segment <= 28
+}
@@ -0,0 +1,87 @@
+package profiler
+
+import "testing"
+
+func TestFiscalCodeValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator func(string) bool
+		value     string
+		expected  bool
+	}{
+		{"CFOP Saída Interna", IsValidCFOP, "5102", true},
+		{"CFOP Entrada com Ponto", IsValidCFOP, "1.949", true},
+		{"CFOP Exportação", IsValidCFOP, "7101", true},
+		{"CFOP Primeiro Dígito 4", IsValidCFOP, "4102", false},
+		{"CFOP Primeiro Dígito 8", IsValidCFOP, "8102", false},
+		{"CFOP Grupo Zero", IsValidCFOP, "5000", false},
+
+		{"CST ICMS", IsValidCST, "00", true},
+		{"CST ICMS com Origem", IsValidCST, "060", true},
+		{"CST PIS/COFINS", IsValidCST, "01", true},
+		{"CST Inexistente", IsValidCST, "15", false},
+		{"CST Origem Inválida", IsValidCST, "960", false},
+
+		{"CSOSN", IsValidCSOSN, "102", true},
+		{"CSOSN com Origem", IsValidCSOSN, "0500", true},
+		{"CSOSN Inexistente", IsValidCSOSN, "104", false},
+
+		{"CEST Formatado", IsValidCEST, "01.001.00", true},
+		{"CEST Limpo", IsValidCEST, "2806300", true},
+		{"CEST Segmento Inexistente", IsValidCEST, "29.001.00", false},
+
+		{"NCM Válido", IsValidNCM, "0101.21.00", true},
+		{"NCM Capítulo 77", IsValidNCM, "77010000", false},
+		{"NCM Capítulo 98", IsValidNCM, "98010000", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validator(tt.value); got != tt.expected {
+				t.Errorf("validação de %q = %v; esperava %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFiscalCodeInference(t *testing.T) {
+	tests := []struct {
+		value    string
+		header   string
+		expected DataType
+	}{
+		{"5102", "cfop", TypeCFOP},
+		{"5.102", "CFOP_ITEM", TypeCFOP},
+		{"060", "cst_icms", TypeCST},
+		{"102", "csosn", TypeCSOSN},
+		{"0100100", "cest", TypeCEST},
+		{"01.001.00", "obs", TypeCEST},
+		{"0101.21.00", "obs", TypeNCM},
+		{"5102", "quantidade", TypeInteger},
+	}
+
+	for _, tt := range tests {
+		if got := InferType(tt.value, tt.header); got != tt.expected {
+			t.Errorf("InferType(%q, %q) = %v; esperava %v", tt.value, tt.header, got, tt.expected)
+		}
+	}
+}
+
+func TestCFOPConsistency(t *testing.T) {
+	acc := NewColumnAccumulator("cfop")
+	for _, v := range []string{"5102", "6102", "1949", "4102"} {
+		acc.Add(v)
+	}
+
+	result := acc.Result()
+
+	if result.MainType != TypeCFOP {
+		t.Fatalf("Esperava MainType CFOP, recebeu %s", result.MainType)
+	}
+	if result.InvalidCount != 1 {
+		t.Errorf("Esperava 1 CFOP inválido, recebeu %d", result.InvalidCount)
+	}
+	if result.ConsistencyRatio != 0.75 {
+		t.Errorf("Esperava consistência 0.75, recebeu %f", result.ConsistencyRatio)
+	}
+}
@@ -0,0 +1,145 @@
+package profiler
+
+// FiscalKey representa as partes de uma chave de acesso de 44 dígitos
+// (NF-e, CT-e, MDF-e, NFC-e).
+type FiscalKey struct {
+	UF           string // cUF (código IBGE)
+	YearMonth    string // AAMM da emissão
+	EmitterCNPJ  string
+	Model        string // 55, 57, 58, 65
+	Series       string
+	Number       string
+	EmissionType string // tpEmis
+	Code         string // cNF
+	CheckDigit   string // cDV
+}
+
+// FiscalKeyProfile resume a decomposição das chaves de acesso de uma coluna.
+type FiscalKeyProfile struct {
+	ByUF            map[string]int `json:"by_uf"`
+	ByModel         map[string]int `json:"by_model"`
+	ByEmissionMonth map[string]int `json:"by_emission_month"`
+	ByEmissionType  map[string]int `json:"by_emission_type"`
+	InvalidDVCount  int            `json:"invalid_dv_count"`
+	InvalidDVRatio  float64        `json:"invalid_dv_ratio"`
+}
+
+var ufByCode = map[string]string{
+	"11": "RO", "12": "AC", "13": "AM", "14": "RR", "15": "PA", "16": "AP", "17": "TO",
+	"21": "MA", "22": "PI", "23": "CE", "24": "RN", "25": "PB", "26": "PE", "27": "AL", "28": "SE", "29": "BA",
+	"31": "MG", "32": "ES", "33": "RJ", "35": "SP",
+	"41": "PR", "42": "SC", "43": "RS",
+	"50": "MS", "51": "MT", "52": "GO", "53": "DF",
+}
+
+var fiscalModelNames = map[string]string{
+	"55": "NF-e",
+	"57": "CT-e",
+	"58": "MDF-e",
+	"65": "NFC-e",
+}
+
+// DecodeFiscalKey separa a chave de acesso em seus campos. Retorna false se
+// o valor não tiver exatamente 44 dígitos.
+func DecodeFiscalKey(key string) (FiscalKey, bool) {
+	if !matchFiscalKey(key) {
+		return FiscalKey{}, false
+	}
+	return FiscalKey{
+		UF:           key[0:2],
+		YearMonth:    key[2:6],
+		EmitterCNPJ:  key[6:20],
+		Model:        key[20:22],
+		Series:       key[22:25],
+		Number:       key[25:34],
+		EmissionType: key[34:35],
+		Code:         key[35:43],
+		CheckDigit:   key[43:44],
+	}, true
+}
+
+// IsValidFiscalKey confere o dígito verificador (módulo 11, pesos 2 a 9
+// aplicados da direita para a esquerda) da chave de acesso.
+func IsValidFiscalKey(key string) bool {
+	if !matchFiscalKey(key) {
+		return false
+	}
+
+	sum := 0
+	weight := 2
+	for i := 42; i >= 0; i-- {
+		sum += int(key[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+	return int(key[43]-'0') == mod11Digit(sum)
+}
+
+type fiscalKeyTally struct {
+	total      int
+	invalidDV  int
+	byUF       map[string]int
+	byModel    map[string]int
+	byMonth    map[string]int
+	byEmission map[string]int
+}
+
+func newFiscalKeyTally() *fiscalKeyTally {
+	return &fiscalKeyTally{
+		byUF:       make(map[string]int),
+		byModel:    make(map[string]int),
+		byMonth:    make(map[string]int),
+		byEmission: make(map[string]int),
+	}
+}
+
+func (ft *fiscalKeyTally) add(value string) {
+	key, ok := DecodeFiscalKey(value)
+	if !ok {
+		return
+	}
+	ft.total++
+	if !IsValidFiscalKey(value) {
+		ft.invalidDV++
+	}
+
+	uf, known := ufByCode[key.UF]
+	if !known {
+		uf = "DESCONHECIDA_" + key.UF
+	}
+	ft.byUF[uf]++
+
+	model, known := fiscalModelNames[key.Model]
+	if !known {
+		model = "MODELO_" + key.Model
+	}
+	ft.byModel[model]++
+
+	ft.byMonth["20"+key.YearMonth[0:2]+"-"+key.YearMonth[2:4]]++
+	ft.byEmission[key.EmissionType]++
+}
+
+func (ft *fiscalKeyTally) merge(other *fiscalKeyTally) {
+	ft.total += other.total
+	ft.invalidDV += other.invalidDV
+	mergeCounts(ft.byUF, other.byUF)
+	mergeCounts(ft.byModel, other.byModel)
+	mergeCounts(ft.byMonth, other.byMonth)
+	mergeCounts(ft.byEmission, other.byEmission)
+}
+
+func (ft *fiscalKeyTally) profile() *FiscalKeyProfile {
+	if ft == nil || ft.total == 0 {
+		return nil
+	}
+	return &FiscalKeyProfile{
+		ByUF:            ft.byUF,
+		ByModel:         ft.byModel,
+		ByEmissionMonth: ft.byMonth,
+		ByEmissionType:  ft.byEmission,
+		InvalidDVCount:  ft.invalidDV,
+		InvalidDVRatio:  float64(ft.invalidDV) / float64(ft.total),
+	}
+}
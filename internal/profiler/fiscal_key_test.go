@@ -0,0 +1,90 @@
+package profiler
+
+import "testing"
+
+func TestDecodeFiscalKey(t *testing.T) {
+	key, ok := DecodeFiscalKey("35230912345678000190550010000000011000000009")
+	if !ok {
+		t.Fatal("Chave de 44 dígitos deveria ser decodificada")
+	}
+
+	expected := FiscalKey{
+		UF:           "35",
+		YearMonth:    "2309",
+		EmitterCNPJ:  "12345678000190",
+		Model:        "55",
+		Series:       "001",
+		Number:       "000000001",
+		EmissionType: "1",
+		Code:         "00000000",
+		CheckDigit:   "9",
+	}
+	if key != expected {
+		t.Errorf("Decodificação incorreta.\nrecebeu:  %+v\nesperava: %+v", key, expected)
+	}
+
+	if _, ok := DecodeFiscalKey("123"); ok {
+		t.Error("Valor curto não deveria ser decodificado")
+	}
+}
+
+func TestIsValidFiscalKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{"35230912345678000190550010000000011000000009", true},
+		{"41240111222333000181570010000123451000000016", true},
+		{"35230912345678000190550010000000011000000000", false},
+		{"3523091234567800019055001000000001100000000", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidFiscalKey(tt.key); got != tt.expected {
+			t.Errorf("IsValidFiscalKey(%q) = %v; esperava %v", tt.key, got, tt.expected)
+		}
+	}
+}
+
+func TestFiscalKeyProfile(t *testing.T) {
+	acc := NewColumnAccumulator("chave_acesso")
+	acc.Add("35230912345678000190550010000000011000000009")
+	acc.Add("35240211222333000181650010000007771000000029")
+	acc.Add("41240111222333000181570010000123451000000016")
+	acc.Add("35230912345678000190550010000000011000000000")
+
+	result := acc.Result()
+
+	if result.MainType != TypeFiscalKey44 {
+		t.Fatalf("Esperava MainType FISCAL_KEY_44, recebeu %s", result.MainType)
+	}
+
+	profile := result.FiscalKeyProfile
+	if profile == nil {
+		t.Fatal("FiscalKeyProfile não deveria ser nil para coluna de chaves")
+	}
+
+	if profile.ByUF["SP"] != 3 || profile.ByUF["PR"] != 1 {
+		t.Errorf("Distribuição por UF incorreta: %v", profile.ByUF)
+	}
+	if profile.ByModel["NF-e"] != 2 || profile.ByModel["NFC-e"] != 1 || profile.ByModel["CT-e"] != 1 {
+		t.Errorf("Distribuição por modelo incorreta: %v", profile.ByModel)
+	}
+	if profile.ByEmissionMonth["2023-09"] != 2 || profile.ByEmissionMonth["2024-01"] != 1 {
+		t.Errorf("Distribuição por mês incorreta: %v", profile.ByEmissionMonth)
+	}
+	if profile.InvalidDVCount != 1 || profile.InvalidDVRatio != 0.25 {
+		t.Errorf("Esperava 1 DV inválido (25%%), recebeu %d (%f)", profile.InvalidDVCount, profile.InvalidDVRatio)
+	}
+	if result.InvalidCount != 1 {
+		t.Errorf("DV inválido deveria contar em InvalidCount, recebeu %d", result.InvalidCount)
+	}
+
+	sync := AnalyzeColumn(Column{Name: "chave_acesso", Values: []string{
+		"35230912345678000190550010000000011000000009",
+		"35230912345678000190550010000000011000000000",
+	}})
+	if sync.FiscalKeyProfile == nil || sync.FiscalKeyProfile.InvalidDVCount != 1 {
+		t.Errorf("AnalyzeColumn deveria gerar o mesmo perfil de chaves, recebeu %+v", sync.FiscalKeyProfile)
+	}
+}
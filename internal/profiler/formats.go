@@ -0,0 +1,53 @@
+package profiler
+
+const (
+	FormatCNPJNumeric      = "NUMERIC"
+	FormatCNPJAlphanumeric = "ALPHANUMERIC"
+
+	FormatPlacaLegacy   = "LEGACY"
+	FormatPlacaMercosul = "MERCOSUL"
+)
+
+// formatClassifiers identificam a variante de formato de tipos que convivem
+// com mais de um leiaute válido, para acompanhar migrações de padrão.
+var formatClassifiers = map[DataType]func(string) string{
+	TypeCNPJ:  cnpjFormat,
+	TypePlaca: placaFormat,
+	TypeEAN:   gtinFormat,
+}
+
+// classifyFormat retorna "" quando o tipo não possui variantes.
+func classifyFormat(t DataType, value string) string {
+	classifier, ok := formatClassifiers[t]
+	if !ok {
+		return ""
+	}
+	return classifier(value)
+}
+
+func cnpjFormat(value string) string {
+	if hasLetter(value) {
+		return FormatCNPJAlphanumeric
+	}
+	return FormatCNPJNumeric
+}
+
+// placaFormat separa placas antigas (ABC-1234) das Mercosul (ABC1D23), que
+// trazem uma letra na quinta posição.
+func placaFormat(value string) string {
+	last := value[len(value)-4:]
+	if hasLetter(last) {
+		return FormatPlacaMercosul
+	}
+	return FormatPlacaLegacy
+}
+
+func hasLetter(value string) bool {
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package profiler
+
+import "testing"
+
+func TestPlacaFormatCounts(t *testing.T) {
+	values := []string{"ABC-1234", "ABC1234", "BRA2E19", "XYZ9A88", "KLM5F20"}
+
+	acc := NewColumnAccumulator("placa_veiculo")
+	for _, v := range values {
+		acc.Add(v)
+	}
+	streaming := acc.Result()
+	sync := AnalyzeColumn(Column{Name: "placa_veiculo", Values: values})
+
+	for name, result := range map[string]ColumnResult{"streaming": streaming, "sync": sync} {
+		if result.MainType != TypePlaca {
+			t.Fatalf("[%s] Esperava MainType LICENSE_PLATE, recebeu %s", name, result.MainType)
+		}
+		if result.FormatCounts[FormatPlacaLegacy] != 2 {
+			t.Errorf("[%s] Esperava 2 placas antigas, recebeu %d", name, result.FormatCounts[FormatPlacaLegacy])
+		}
+		if result.FormatCounts[FormatPlacaMercosul] != 3 {
+			t.Errorf("[%s] Esperava 3 placas Mercosul, recebeu %d", name, result.FormatCounts[FormatPlacaMercosul])
+		}
+		if result.Sensitivity != SensitivityInternal {
+			t.Errorf("[%s] Esperava sensibilidade INTERNAL, recebeu %s", name, result.Sensitivity)
+		}
+	}
+}
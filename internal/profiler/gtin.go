@@ -0,0 +1,167 @@
+package profiler
+
+import "strconv"
+
+// GTINProfile resume os códigos de barras (EAN-8, EAN-13 e GTIN-14) de uma
+// coluna pelo país do prefixo GS1.
+type GTINProfile struct {
+	ByCountry      map[string]int `json:"by_country"`
+	InvalidDVCount int            `json:"invalid_dv_count"`
+	InvalidDVRatio float64        `json:"invalid_dv_ratio"`
+}
+
+type gs1PrefixRange struct {
+	from, to int
+	country  string
+}
+
+// gs1Prefixes lista as faixas de prefixo GS1 mais comuns no comércio com o
+// Brasil. Faixas fora da lista são reportadas como "PREFIXO_nnn".
+var gs1Prefixes = []gs1PrefixRange{
+	{0, 19, "EUA/Canadá"},
+	{20, 29, "Uso Interno"},
+	{30, 39, "EUA/Canadá"},
+	{40, 49, "Uso Interno"},
+	{50, 59, "Cupons"},
+	{60, 139, "EUA/Canadá"},
+	{200, 299, "Uso Interno"},
+	{300, 379, "França"},
+	{400, 440, "Alemanha"},
+	{450, 459, "Japão"},
+	{460, 469, "Rússia"},
+	{471, 471, "Taiwan"},
+	{489, 489, "Hong Kong"},
+	{490, 499, "Japão"},
+	{500, 509, "Reino Unido"},
+	{540, 549, "Bélgica/Luxemburgo"},
+	{560, 560, "Portugal"},
+	{570, 579, "Dinamarca"},
+	{590, 590, "Polônia"},
+	{600, 601, "África do Sul"},
+	{690, 699, "China"},
+	{729, 729, "Israel"},
+	{730, 739, "Suécia"},
+	{750, 750, "México"},
+	{754, 755, "Canadá"},
+	{759, 759, "Venezuela"},
+	{760, 769, "Suíça"},
+	{770, 771, "Colômbia"},
+	{773, 773, "Uruguai"},
+	{775, 775, "Peru"},
+	{777, 777, "Bolívia"},
+	{778, 779, "Argentina"},
+	{780, 780, "Chile"},
+	{784, 784, "Paraguai"},
+	{786, 786, "Equador"},
+	{789, 790, "Brasil"},
+	{800, 839, "Itália"},
+	{840, 849, "Espanha"},
+	{868, 869, "Turquia"},
+	{870, 879, "Holanda"},
+	{880, 880, "Coreia do Sul"},
+	{885, 885, "Tailândia"},
+	{888, 888, "Singapura"},
+	{890, 890, "Índia"},
+	{893, 893, "Vietnã"},
+	{899, 899, "Indonésia"},
+	{900, 919, "Áustria"},
+	{930, 939, "Austrália"},
+	{940, 949, "Nova Zelândia"},
+	{955, 955, "Malásia"},
+	{977, 977, "Publicações Seriadas (ISSN)"},
+	{978, 979, "Livros (ISBN)"},
+	{981, 999, "Cupons"},
+}
+
+// IsValidGTIN confere o dígito verificador GS1 (módulo 10, pesos 3 e 1
+// alternados a partir da direita) de EAN-8, EAN-13 e GTIN-14.
+func IsValidGTIN(value string) bool {
+	switch len(value) {
+	case 8, 13, 14:
+	default:
+		return false
+	}
+	if !isDigits(value) {
+		return false
+	}
+
+	sum := 0
+	weight := 3
+	for i := len(value) - 2; i >= 0; i-- {
+		sum += int(value[i]-'0') * weight
+		weight = 4 - weight
+	}
+	return int(value[len(value)-1]-'0') == (10-sum%10)%10
+}
+
+// GS1Country decodifica o país (ou uso reservado) do prefixo GS1. No GTIN-14
+// o primeiro dígito é o indicador de embalagem e é ignorado.
+func GS1Country(gtin string) (string, bool) {
+	switch len(gtin) {
+	case 14:
+		gtin = gtin[1:]
+	case 8, 13:
+	default:
+		return "", false
+	}
+	prefix, err := strconv.Atoi(gtin[:3])
+	if err != nil {
+		return "", false
+	}
+
+	for _, r := range gs1Prefixes {
+		if prefix >= r.from && prefix <= r.to {
+			return r.country, true
+		}
+	}
+	return "PREFIXO_" + gtin[:3], true
+}
+
+func gtinFormat(value string) string {
+	switch len(value) {
+	case 8:
+		return "EAN-8"
+	case 13:
+		return "EAN-13"
+	}
+	return "GTIN-14"
+}
+
+type gtinTally struct {
+	total     int
+	invalidDV int
+	byCountry map[string]int
+}
+
+func newGTINTally() *gtinTally {
+	return &gtinTally{byCountry: make(map[string]int)}
+}
+
+func (gt *gtinTally) add(value string) {
+	country, ok := GS1Country(value)
+	if !ok {
+		return
+	}
+	gt.total++
+	if !IsValidGTIN(value) {
+		gt.invalidDV++
+	}
+	gt.byCountry[country]++
+}
+
+func (gt *gtinTally) merge(other *gtinTally) {
+	gt.total += other.total
+	gt.invalidDV += other.invalidDV
+	mergeCounts(gt.byCountry, other.byCountry)
+}
+
+func (gt *gtinTally) profile() *GTINProfile {
+	if gt == nil || gt.total == 0 {
+		return nil
+	}
+	return &GTINProfile{
+		ByCountry:      gt.byCountry,
+		InvalidDVCount: gt.invalidDV,
+		InvalidDVRatio: float64(gt.invalidDV) / float64(gt.total),
+	}
+}
@@ -0,0 +1,85 @@
+package profiler
+
+import "testing"
+
+func TestIsValidGTIN(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{"EAN-13 Válido", "7891000315507", true},
+		{"EAN-13 Dígito Errado", "7891000315508", false},
+		{"EAN-8 Válido", "96385074", true},
+		{"GTIN-14 Válido", "17891000315504", true},
+		{"Tamanho Errado", "789100031550", false},
+		{"Não Numérico", "789100031550A", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidGTIN(tt.value); got != tt.expected {
+				t.Errorf("IsValidGTIN(%q) = %v; esperava %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGS1Country(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"7891000315507", "Brasil"},
+		{"7901234567892", "Brasil"},
+		{"17891000315504", "Brasil"},
+		{"0012345678905", "EUA/Canadá"},
+		{"4006381333931", "Alemanha"},
+		{"9788535902778", "Livros (ISBN)"},
+		{"4891234567893", "Hong Kong"},
+		{"4831234567890", "PREFIXO_483"},
+	}
+
+	for _, tt := range tests {
+		if got, _ := GS1Country(tt.value); got != tt.expected {
+			t.Errorf("GS1Country(%q) = %q; esperava %q", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestGTINProfile(t *testing.T) {
+	values := []string{"7891000315507", "7891000315508", "4006381333931", "17891000315504", "96385074"}
+
+	acc := NewColumnAccumulator("ean_produto")
+	for _, v := range values {
+		acc.Add(v)
+	}
+	streaming := acc.Result()
+	sync := AnalyzeColumn(Column{Name: "ean_produto", Values: values})
+
+	for name, result := range map[string]ColumnResult{"streaming": streaming, "sync": sync} {
+		if result.MainType != TypeEAN {
+			t.Fatalf("[%s] Esperava MainType EAN_PRODUCT, recebeu %s", name, result.MainType)
+		}
+		if result.InvalidCount != 1 || result.ValidCount != 4 {
+			t.Errorf("[%s] Esperava 4 válidos e 1 inválido, recebeu %d/%d", name, result.ValidCount, result.InvalidCount)
+		}
+		if result.ConsistencyRatio != 0.8 {
+			t.Errorf("[%s] Esperava consistência 0.8, recebeu %f", name, result.ConsistencyRatio)
+		}
+		if result.FormatCounts["EAN-13"] != 3 || result.FormatCounts["GTIN-14"] != 1 || result.FormatCounts["EAN-8"] != 1 {
+			t.Errorf("[%s] FormatCounts inesperado: %v", name, result.FormatCounts)
+		}
+
+		profile := result.GTINProfile
+		if profile == nil {
+			t.Fatalf("[%s] GTINProfile não deveria ser nil", name)
+		}
+		if profile.ByCountry["Brasil"] != 3 || profile.ByCountry["Alemanha"] != 1 {
+			t.Errorf("[%s] ByCountry inesperado: %v", name, profile.ByCountry)
+		}
+		if profile.InvalidDVCount != 1 {
+			t.Errorf("[%s] Esperava 1 dígito inválido, recebeu %d", name, profile.InvalidDVCount)
+		}
+	}
+}
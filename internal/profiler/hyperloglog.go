@@ -0,0 +1,146 @@
+package profiler
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Limites de precisão do HyperLogLog: 2^p registradores de um byte. A
+// precisão padrão (14) usa 16 KiB por coluna com erro padrão de ~0,8%.
+const (
+	hllMinPrecision     = 4
+	hllMaxPrecision     = 18
+	hllDefaultPrecision = 14
+)
+
+// Até este número de distintos os hashes são guardados e a contagem é exata;
+// acima dele vale só a estimativa dos registradores.
+const hllExactLimit = 1024
+
+// hyperLogLog estima a quantidade de valores distintos sem guardá-los.
+type hyperLogLog struct {
+	precision uint8
+	registers []uint8
+	exact     map[uint64]struct{}
+}
+
+func newHyperLogLog(precision uint8) *hyperLogLog {
+	precision = min(max(precision, hllMinPrecision), hllMaxPrecision)
+	return &hyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+		exact:     make(map[uint64]struct{}),
+	}
+}
+
+func (h *hyperLogLog) add(value string) {
+	hash := hashString(value)
+	if h.exact != nil {
+		h.exact[hash] = struct{}{}
+		if len(h.exact) > hllExactLimit {
+			h.exact = nil
+		}
+	}
+
+	index := hash >> (64 - h.precision)
+	rank := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1))) + 1
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+// merge une dois sketches de mesma precisão (máximo por registrador).
+func (h *hyperLogLog) merge(other *hyperLogLog) {
+	if other == nil || other.precision != h.precision {
+		return
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+
+	if h.exact == nil || other.exact == nil {
+		h.exact = nil
+		return
+	}
+	for hash := range other.exact {
+		h.exact[hash] = struct{}{}
+	}
+	if len(h.exact) > hllExactLimit {
+		h.exact = nil
+	}
+}
+
+func (h *hyperLogLog) estimate() int {
+	if h.exact != nil {
+		return len(h.exact)
+	}
+
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	switch len(h.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	}
+	estimate := alpha * m * m / sum
+
+	// Correção para cardinalidades baixas: contagem linear.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int(math.Round(estimate))
+}
+
+// standardError é o erro relativo típico da estimativa (1,04/√m).
+func (h *hyperLogLog) standardError() float64 {
+	return 1.04 / math.Sqrt(float64(len(h.registers)))
+}
+
+// hashString aplica FNV-1a de 64 bits seguido do finalizador do SplitMix64,
+// que espalha os bits como o HyperLogLog exige e mantém o resultado estável
+// entre execuções.
+func hashString(s string) uint64 {
+	hash := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		hash ^= uint64(s[i])
+		hash *= 1099511628211
+	}
+	hash ^= hash >> 30
+	hash *= 0xbf58476d1ce4e5b9
+	hash ^= hash >> 27
+	hash *= 0x94d049bb133111eb
+	hash ^= hash >> 31
+	return hash
+}
+
+// distinctMetrics resume a cardinalidade de uma coluna. Uma coluna é
+// candidata a chave primária quando não tem vazios e todos os valores são
+// distintos: exatamente, em colunas pequenas, ou dentro de duas vezes o erro
+// padrão da estimativa.
+func distinctMetrics(h *hyperLogLog, filled, blank int) (distinct int, uniqueness float64, candidateKey bool) {
+	if filled == 0 {
+		return 0, 0, false
+	}
+	distinct = min(h.estimate(), filled)
+	uniqueness = float64(distinct) / float64(filled)
+	tolerance := 2 * h.standardError()
+	if h.exact != nil {
+		tolerance = 0
+	}
+	candidateKey = blank == 0 && filled > 1 && uniqueness >= 1-tolerance
+	return distinct, uniqueness, candidateKey
+}
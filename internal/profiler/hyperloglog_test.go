@@ -0,0 +1,120 @@
+package profiler
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestHyperLogLog_Estimate(t *testing.T) {
+	t.Run("Contagem exata em colunas pequenas", func(t *testing.T) {
+		h := newHyperLogLog(hllDefaultPrecision)
+		for i := 0; i < 500; i++ {
+			h.add(strconv.Itoa(i % 300))
+		}
+		if got := h.estimate(); got != 300 {
+			t.Errorf("Esperava 300 distintos, recebeu %d", got)
+		}
+	})
+
+	for _, precision := range []uint8{10, 14} {
+		t.Run("Alta cardinalidade p="+strconv.Itoa(int(precision)), func(t *testing.T) {
+			const n = 200_000
+			h := newHyperLogLog(precision)
+			for i := 0; i < n; i++ {
+				h.add("pedido-" + strconv.Itoa(i))
+				h.add("pedido-" + strconv.Itoa(i)) // repetições não contam
+			}
+
+			got := float64(h.estimate())
+			if relErr := math.Abs(got-n) / n; relErr > 3*h.standardError() {
+				t.Errorf("Estimativa %.0f fora do erro esperado (%.3f > %.3f)", got, relErr, 3*h.standardError())
+			}
+		})
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	left, right, whole := newHyperLogLog(12), newHyperLogLog(12), newHyperLogLog(12)
+	for i := 0; i < 50_000; i++ {
+		v := strconv.Itoa(i)
+		whole.add(v)
+		if i%2 == 0 {
+			left.add(v)
+		} else {
+			right.add(v)
+		}
+	}
+	left.merge(right)
+
+	if left.estimate() != whole.estimate() {
+		t.Errorf("Merge deveria equivaler ao sketch único: %d != %d", left.estimate(), whole.estimate())
+	}
+}
+
+func TestDistinctMetrics(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       []string
+		candidateKey bool
+		distinct     int
+	}{
+		{"IDs Únicos", []string{"1", "2", "3", "4"}, true, 4},
+		{"Chave Duplicada", []string{"1", "2", "2", "4"}, false, 3},
+		{"Único com Vazio", []string{"1", "2", "", "4"}, false, 3},
+		{"Único Valor", []string{"1"}, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := NewColumnAccumulator("id")
+			for _, v := range tt.values {
+				acc.Add(v)
+			}
+			streaming := acc.Result()
+			sync := AnalyzeColumn(Column{Name: "id", Values: tt.values})
+
+			for name, result := range map[string]ColumnResult{"streaming": streaming, "sync": sync} {
+				if result.DistinctEstimate != tt.distinct {
+					t.Errorf("[%s] Esperava %d distintos, recebeu %d", name, tt.distinct, result.DistinctEstimate)
+				}
+				if result.IsCandidateKey != tt.candidateKey {
+					t.Errorf("[%s] IsCandidateKey = %v; esperava %v", name, result.IsCandidateKey, tt.candidateKey)
+				}
+			}
+		})
+	}
+}
+
+func TestWithHLLPrecision(t *testing.T) {
+	acc := NewColumnAccumulator("id", WithHLLPrecision(8))
+	if len(acc.distinct.registers) != 256 {
+		t.Errorf("Esperava 256 registradores, recebeu %d", len(acc.distinct.registers))
+	}
+
+	acc = NewColumnAccumulator("id", WithHLLPrecision(40))
+	if acc.distinct.precision != hllMaxPrecision {
+		t.Errorf("Precisão deveria ser limitada a %d, recebeu %d", hllMaxPrecision, acc.distinct.precision)
+	}
+}
+
+func TestDistinctMetrics_LargeKeyColumn(t *testing.T) {
+	acc := NewColumnAccumulator("id_pedido")
+	for i := 0; i < 100_000; i++ {
+		acc.Add("PED" + strconv.Itoa(i))
+	}
+	unique := acc.Result()
+
+	dup := NewColumnAccumulator("id_pedido")
+	for i := 0; i < 100_000; i++ {
+		dup.Add("PED" + strconv.Itoa(i%90_000))
+	}
+	duplicated := dup.Result()
+
+	if !unique.IsCandidateKey {
+		t.Errorf("Coluna de IDs únicos deveria ser candidata a chave (unicidade %.4f)", unique.UniquenessRatio)
+	}
+	if duplicated.IsCandidateKey {
+		t.Errorf("Coluna com 10%% de repetições não deveria ser candidata (unicidade %.4f)", duplicated.UniquenessRatio)
+	}
+}
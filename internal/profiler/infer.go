@@ -2,9 +2,7 @@ package profiler
 
 import (
 	"regexp"
-	"strconv"
 	"strings"
-	"time"
 )
 
 // --8<-- [start:infer_data_type]
@@ -12,18 +10,37 @@ var (
 	// Essenciais
 	RegexFiscalKey = regexp.MustCompile(`^\d{44}$`)                          // NFe, CTe, MDFe
 	RegexPlaca     = regexp.MustCompile(`^[A-Z]{3}-?[0-9][0-9A-Z][0-9]{2}$`) // ABC1234 ou ABC1C34
+	RegexVIN       = regexp.MustCompile(`^[A-HJ-NPR-Z0-9]{17}$`)             // Chassi (ISO 3779, sem I, O e Q)
 
 	// Documentos Brasileiros
-	RegexCPF  = regexp.MustCompile(`^\d{3}\.\d{3}\.\d{3}-\d{2}$`)       // 000.000.000-00
-	RegexCNPJ = regexp.MustCompile(`^\d{2}\.\d{3}\.\d{3}/\d{4}-\d{2}$`) // 00.000.000/0000-00
+	RegexCPF       = regexp.MustCompile(`^\d{3}\.\d{3}\.\d{3}-\d{2}$`)                               // 000.000.000-00
+	RegexCNPJ      = regexp.MustCompile(`^[0-9A-Z]{2}\.[0-9A-Z]{3}\.[0-9A-Z]{3}/[0-9A-Z]{4}-\d{2}$`) // 00.000.000/0000-00 ou AB.12C.D34/5E6F-00
+	RegexCNPJAlnum = regexp.MustCompile(`^[0-9A-Z]{12}\d{2}$`)                                       // CNPJ alfanumérico sem máscara (2026+)
+	RegexPIS       = regexp.MustCompile(`^\d{3}\.\d{5}\.\d{2}-\d$`)                                  // PIS/PASEP/NIS 000.00000.00-0
+	RegexRENAVAM   = regexp.MustCompile(`^(\d{9}|\d{11})$`)                                          // RENAVAM (9 dígitos antes de 2013)
+	RegexTitulo    = regexp.MustCompile(`^\d{4} ?\d{4} ?\d{4}$`)                                     // Título de eleitor 0000 0000 0000
+	RegexCNS       = regexp.MustCompile(`^\d{3} ?\d{4} ?\d{4} ?\d{4}$`)                              // Cartão SUS 000 0000 0000 0000
+
+	// Códigos fiscais
+	RegexNCM   = regexp.MustCompile(`^\d{4}\.\d{2}\.\d{2}$`)   // NCM com máscara 0000.00.00
+	RegexCFOP  = regexp.MustCompile(`^\d\.?\d{3}$`)            // 5.102 ou 5102
+	RegexCST   = regexp.MustCompile(`^\d{2,3}$`)               // CST com ou sem origem
+	RegexCSOSN = regexp.MustCompile(`^\d{3,4}$`)               // CSOSN com ou sem origem
+	RegexCEST  = regexp.MustCompile(`^\d{2}\.?\d{3}\.?\d{2}$`) // 01.001.00
 
 	// Datas (Formatos comuns BR e ISO)
 	RegexDateBr  = regexp.MustCompile(`^\d{2}/\d{2}/\d{4}$`) // DD/MM/YYYY
 	RegexDateIso = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`) // YYYY-MM-DD
 
+	// Data/hora, hora do dia e epoch
+	RegexDatetimeIso = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}(:\d{2}(\.\d{1,9})?)?(Z|[+-]\d{2}:?\d{2})?$`) // 2024-05-01T13:45:00Z
+	RegexDatetimeBr  = regexp.MustCompile(`^\d{2}/\d{2}/\d{4} \d{2}:\d{2}(:\d{2})?$`)                                     // 01/05/2024 13:45
+	RegexTime        = regexp.MustCompile(`^\d{2}:\d{2}(:\d{2}(\.\d{1,9})?)?$`)                                           // 13:45:00
+	RegexEpoch       = regexp.MustCompile(`^\d{10}(\d{3})?$`)                                                             // Segundos ou milissegundos
+
 	// Logística Avançada
 	RegexContainer = regexp.MustCompile(`^[A-Z]{4}\d{7}$`)                  // Padrão ISO
-	Regex8Digits   = regexp.MustCompile(`^\d{8}$`)                          // NCM, RNTRC, CEP sem traço, Data compacta
+	Regex8Digits   = regexp.MustCompile(`^\d{8}$`)                          // NCM, RNTRC, CEP sem traço, Data compacta, EAN-8
 	Regex11Digits  = regexp.MustCompile(`^\d{11}$`)                         // CPF sem formatação
 	RegexCEP       = regexp.MustCompile(`^\d{5}-\d{3}$`)                    // CEP com traço
 	RegexMobile    = regexp.MustCompile(`^\(?\d{2}\)?\s?9\d{4}-?\d{4}$`)    // Celular com 9
@@ -33,84 +50,181 @@ var (
 
 // --8<-- [end:infer_data_type]
 
+// InferType classifica o valor usando o registro padrão de detectores.
 func InferType(value string, headerName string) DataType {
-	if value == "" {
-		return TypeEmpty
-	}
-	headerLower := strings.ToLower(headerName)
+	return defaultRegistry.Infer(value, headerName)
+}
 
-	if Regex8Digits.MatchString(value) {
-		if containsAny(headerLower, "ncm", "fiscal", "classificacao", "sh") {
-			return TypeNCM
-		}
-		if containsAny(headerLower, "rntrc", "antt", "transportador") {
-			return TypeRNTRC
-		}
-		if containsAny(headerLower, "cep", "zip", "postal") {
-			return TypeCEP
-		}
-		if isCompactDate(value) {
-			return TypeDateCompact
-		}
-	}
+type typeMeta struct {
+	priority    int
+	sensitivity DataSensitivity
+	reason      string
+	severity    SeverityLevel
+	checksum    func(string) bool
+}
 
-	if RegexFiscalKey.MatchString(value) {
-		return TypeFiscalKey44
-	}
-	if RegexEmail.MatchString(value) {
-		return TypeEmail
-	}
-	if RegexPlaca.MatchString(value) {
-		return TypePlaca
-	}
-	if RegexContainer.MatchString(value) {
-		return TypeContainer
-	}
-	if RegexCEP.MatchString(value) {
-		return TypeCEP
-	}
-	if RegexMobile.MatchString(value) {
-		return TypeMobile
-	}
+const (
+	reasonPII       = "Identificação Pessoal/Empresarial (PII)"
+	reasonContact   = "Dado de Contato Pessoal (PII)"
+	reasonFiscal    = "Sigilo Fiscal (NFe/CTe)"
+	reasonHealth    = "Dado Pessoal Sensível de Saúde (LGPD Art. 11)"
+	reasonLogistics = "Rastreabilidade Logística (Segurança Operacional)"
+	reasonProduct   = "Inteligência Comercial/Produto"
+	reasonTax       = "Classificação Tributária (Operação Fiscal)"
+	reasonGeneral   = "Dado Geral / Não Classificado"
+)
 
-	if RegexDateBr.MatchString(value) || RegexDateIso.MatchString(value) {
-		return TypeDate
-	}
+// builtinTypes declara prioridade, sensibilidade e severidade de cada tipo
+// nativo. A prioridade define a ordem de avaliação e o desempate do tipo
+// principal; o espaçamento de 10 em 10 deixa lugar para detectores customizados.
+var builtinTypes = map[DataType]typeMeta{
+	TypeFiscalKey44:   {170, SensitivityConfidential, reasonFiscal, SeverityHigh, IsValidFiscalKey},
+	TypeEAN:           {165, SensitivityInternal, reasonProduct, SeverityMedium, IsValidGTIN},
+	TypeCNPJ:          {160, SensitivityConfidential, reasonPII, SeverityHigh, IsValidCNPJ},
+	TypeCNS:           {158, SensitivityConfidential, reasonHealth, SeverityHigh, IsValidCNS},
+	TypeTituloEleitor: {156, SensitivityConfidential, reasonPII, SeverityHigh, IsValidTituloEleitor},
+	TypeVIN:           {155, SensitivityInternal, reasonLogistics, SeverityHigh, IsValidVIN},
+	TypeCNH:           {154, SensitivityConfidential, reasonPII, SeverityHigh, IsValidCNH},
+	TypeRENAVAM:       {153, SensitivityInternal, reasonLogistics, SeverityHigh, IsValidRENAVAM},
+	TypePIS:           {152, SensitivityConfidential, reasonPII, SeverityHigh, IsValidPIS},
+	TypeCPF:           {150, SensitivityConfidential, reasonPII, SeverityHigh, IsValidCPF},
+	TypeNCM:           {148, SensitivityInternal, reasonProduct, SeverityMedium, IsValidNCM},
+	TypeRNTRC:         {146, SensitivityInternal, reasonLogistics, SeverityHigh, nil},
+	TypeCFOP:          {144, SensitivityInternal, reasonTax, SeverityMedium, IsValidCFOP},
+	TypeCSOSN:         {143, SensitivityInternal, reasonTax, SeverityMedium, IsValidCSOSN},
+	TypeCST:           {142, SensitivityInternal, reasonTax, SeverityMedium, IsValidCST},
+	TypeCEST:          {141, SensitivityInternal, reasonTax, SeverityMedium, IsValidCEST},
+	TypeCEP:           {140, SensitivityPublic, reasonGeneral, SeverityMedium, nil},
+	TypeContainer:     {135, SensitivityInternal, reasonLogistics, SeverityHigh, IsValidContainer},
+	TypePlaca:         {130, SensitivityInternal, reasonLogistics, SeverityHigh, nil},
+	TypeMobile:        {125, SensitivityConfidential, reasonContact, SeverityMedium, nil},
+	TypeEmail:         {120, SensitivityConfidential, reasonContact, SeverityMedium, nil},
+	TypeEpoch:         {110, SensitivityPublic, reasonGeneral, SeverityMedium, nil},
+	TypeDatetime:      {100, SensitivityPublic, reasonGeneral, SeverityMedium, isValidDatetime},
+	TypeDateCompact:   {90, SensitivityPublic, reasonGeneral, SeverityMedium, nil},
+	TypeDate:          {80, SensitivityPublic, reasonGeneral, SeverityMedium, nil},
+	TypeTime:          {70, SensitivityPublic, reasonGeneral, SeverityMedium, nil},
+	TypeCurrencyBRL:   {60, SensitivityPublic, reasonGeneral, SeverityMedium, nil},
+	TypePercentage:    {50, SensitivityPublic, reasonGeneral, SeverityMedium, nil},
+	TypeFloat:         {40, SensitivityPublic, reasonGeneral, SeverityMedium, nil},
+	TypeInteger:       {30, SensitivityPublic, reasonGeneral, SeverityMedium, nil},
+	TypeBoolean:       {20, SensitivityPublic, reasonGeneral, SeverityLow, nil},
+}
 
-	if RegexEAN.MatchString(value) {
-		if containsAny(headerLower, "ean", "gtin", "barras", "item", "produto", "sku") {
-			return TypeEAN
-		}
+var (
+	hintsCPF  = []string{"cpf", "cliente", "consumidor", "pessoa", "colaborador", "funcionario", "funcionário", "usuario", "usuário", "rg", "identidade", "documento"}
+	hintsCNPJ = []string{"cnpj", "fornecedor", "empresa", "transportadora"}
+)
 
-		if containsAny(headerLower, "cnpj", "fornecedor", "empresa", "transportadora") {
-			return TypeCNPJ
-		}
-	}
+func builtinDetectors() []TypeDetector {
+	return []TypeDetector{
+		builtin(TypeFiscalKey44, RegexFiscalKey, nil),
 
-	if RegexCNPJ.MatchString(value) {
-		return TypeCNPJ
-	}
+		builtin(TypeEAN, RegexEAN, nil, "ean", "gtin", "barras", "item", "produto", "sku"),
+		// EAN-8 disputa os 8 dígitos com NCM e CEP; só as dicas explícitas valem.
+		builtin(TypeEAN, Regex8Digits, nil, "ean", "gtin", "barras"),
 
-	if Regex11Digits.MatchString(value) {
-		if containsAny(headerLower, "cpf", "cliente", "consumidor", "pessoa", "colaborador", "funcionario", "funcionário", "usuario", "usuário", "rg", "identidade", "documento") {
-			return TypeCPF
-		}
-	}
-	if RegexCPF.MatchString(value) {
-		return TypeCPF
+		builtin(TypeCNPJ, RegexEAN, nil, hintsCNPJ...),
+		builtin(TypeCNPJ, RegexCNPJ, nil),
+		builtin(TypeCNPJ, RegexCNPJAlnum, hasLetter, hintsCNPJ...),
+		builtin(TypeCNPJ, RegexCNPJAlnum, func(v string) bool { return hasLetter(v) && IsValidCNPJ(v) }),
+
+		builtin(TypeCNS, RegexCNS, nil, "cns", "sus", "cartao_saude", "cartão_saúde"),
+		// Sem dica, agrupamentos por espaço (cartões, protocolos) só valem com DV.
+		builtin(TypeCNS, RegexCNS, func(v string) bool { return hasSpace(v) && IsValidCNS(v) }),
+		builtin(TypeTituloEleitor, RegexTitulo, nil, "titulo", "título", "eleitor"),
+		builtin(TypeTituloEleitor, RegexTitulo, func(v string) bool { return hasSpace(v) && IsValidTituloEleitor(v) }),
+		builtin(TypeCNH, Regex11Digits, nil, "cnh", "habilitacao", "habilitação"),
+		builtin(TypeRENAVAM, RegexRENAVAM, nil, "renavam"),
+		builtin(TypePIS, Regex11Digits, nil, "pis", "pasep", "nis_", "_nis", "nit_", "_nit"),
+		builtin(TypePIS, RegexPIS, nil),
+
+		builtin(TypeCPF, Regex11Digits, nil, hintsCPF...),
+		builtin(TypeCPF, RegexCPF, nil),
+
+		builtin(TypeNCM, Regex8Digits, nil, "ncm", "fiscal", "classificacao", "sh"),
+		builtin(TypeNCM, RegexNCM, nil),
+		builtin(TypeRNTRC, Regex8Digits, nil, "rntrc", "antt", "transportador"),
+		builtin(TypeCFOP, RegexCFOP, nil, "cfop"),
+		builtin(TypeCSOSN, RegexCSOSN, nil, "csosn"),
+		builtin(TypeCST, RegexCST, nil, "cst"),
+		builtin(TypeCEST, RegexCEST, nil, "cest"),
+		// Sem dica, só a máscara completa: "12345.67" e "12.34567" são decimais.
+		builtin(TypeCEST, RegexCEST, func(v string) bool { return matchMask(v, "##.###.##") }),
+		builtin(TypeCEP, Regex8Digits, nil, "cep", "zip", "postal"),
+		builtin(TypeCEP, RegexCEP, nil),
+
+		builtin(TypeContainer, RegexContainer, nil),
+		builtin(TypeVIN, RegexVIN, isAlphanumericMix, "chassi", "vin", "veiculo", "veículo", "renavam"),
+		// Sem dica, códigos de 17 caracteres (SKUs, protocolos) só viram chassi
+		// com o dígito verificador válido.
+		builtin(TypeVIN, RegexVIN, func(v string) bool { return isAlphanumericMix(v) && IsValidVIN(v) }),
+		builtin(TypePlaca, RegexPlaca, nil),
+		builtin(TypeMobile, RegexMobile, nil),
+		builtin(TypeEmail, RegexEmail, nil),
+
+		builtin(TypeEpoch, RegexEpoch, isEpoch, "timestamp", "epoch", "unix", "_ts", "ts_"),
+		builtin(TypeDatetime, RegexDatetimeIso, nil),
+		builtin(TypeDatetime, RegexDatetimeBr, nil),
+		builtin(TypeDateCompact, Regex8Digits, isCompactDate),
+		builtin(TypeDate, RegexDateBr, nil),
+		builtin(TypeDate, RegexDateIso, nil),
+		builtin(TypeTime, RegexTime, isClockTime),
+
+		// Sem contexto da coluna, valores ambíguos ("1.234") seguem a convenção pt-BR.
+		builtin(TypeCurrencyBRL, nil, isNumberKind(TypeCurrencyBRL)),
+		builtin(TypePercentage, nil, isNumberKind(TypePercentage)),
+		builtin(TypeFloat, nil, isNumberKind(TypeFloat)),
+		builtin(TypeInteger, nil, isNumberKind(TypeInteger)),
+		builtin(TypeBoolean, nil, isBool),
 	}
+}
 
-	if isInt(value) {
-		return TypeInteger
+func builtin(t DataType, pattern *regexp.Regexp, validate func(string) bool, hints ...string) TypeDetector {
+	meta := builtinTypes[t]
+	detector := &patternDetector{
+		dtype:       t,
+		pattern:     pattern,
+		scan:        scanners[pattern],
+		validate:    validate,
+		hints:       hints,
+		priority:    meta.priority,
+		sensitivity: meta.sensitivity,
+		reason:      meta.reason,
+		severity:    meta.severity,
 	}
-	if isFloat(value) {
-		return TypeFloat
+	if meta.checksum != nil {
+		return &checksumDetector{patternDetector: detector, checksum: meta.checksum}
 	}
-	if isBool(value) {
-		return TypeBoolean
+	return detector
+}
+
+// numberMarkers lista, por tipo numérico, os bytes sem os quais o valor não
+// pode ser daquele tipo, o que poupa o parse na maioria dos casos.
+var numberMarkers = map[DataType]string{
+	TypeCurrencyBRL: "$",
+	TypePercentage:  "%",
+	TypeFloat:       ".,eE",
+}
+
+func isNumberKind(t DataType) func(string) bool {
+	markers := numberMarkers[t]
+	return func(value string) bool {
+		if markers != "" && !strings.ContainsAny(value, markers) {
+			return false
+		}
+		number, ok := scanNumber(value, DecimalComma)
+		return ok && number.kind == t
 	}
+}
 
-	return TypeString
+// isClockTime espera um valor no formato de RegexTime.
+func isClockTime(value string) bool {
+	seconds := 0
+	if len(value) >= 8 {
+		seconds = atoi2(value[6:8])
+	}
+	return atoi2(value[0:2]) < 24 && atoi2(value[3:5]) < 60 && seconds < 60
 }
 
 func containsAny(text string, keywords ...string) bool {
@@ -122,28 +236,49 @@ func containsAny(text string, keywords ...string) bool {
 	return false
 }
 
+// isCompactDate espera 8 dígitos e aceita os que formam uma data como
+// AAAAMMDD ou DDMMAAAA.
 func isCompactDate(value string) bool {
-	_, err := time.Parse("20060102", value)
-	if err == nil {
-		return true
+	if len(value) != 8 {
+		return false
 	}
-	_, err = time.Parse("02012006", value)
-	return err == nil
+	return isCalendarDate(atoi4(value[0:4]), atoi2(value[4:6]), atoi2(value[6:8])) ||
+		isCalendarDate(atoi4(value[4:8]), atoi2(value[2:4]), atoi2(value[0:2]))
 }
 
-func isInt(value string) bool {
-	_, err := strconv.Atoi(value)
+// atoi2 e atoi4 convertem dígitos já validados.
+func atoi2(s string) int { return int(s[0]-'0')*10 + int(s[1]-'0') }
+func atoi4(s string) int { return atoi2(s[0:2])*100 + atoi2(s[2:4]) }
 
-	return err == nil
+// isAlphanumericMix exige letras e dígitos, para que sequências só numéricas
+// de mesmo tamanho não sejam lidas como chassi.
+func isAlphanumericMix(value string) bool {
+	return hasLetter(value) && strings.ContainsAny(value, "0123456789")
+}
+
+func hasSpace(value string) bool {
+	return strings.Contains(value, " ")
 }
 
 func isBool(value string) bool {
-	lower := strings.ToLower(value)
-	return lower == "true" || lower == "false" || lower == "s" || lower == "n"
+	return equalFoldASCII(value, "true") || equalFoldASCII(value, "false") ||
+		equalFoldASCII(value, "s") || equalFoldASCII(value, "n")
 }
 
-func isFloat(value string) bool {
-	value = strings.Replace(value, ",", ".", 1)
-	_, err := strconv.ParseFloat(value, 64)
-	return err == nil
+// equalFoldASCII compara value com lower (em minúsculas) ignorando a caixa
+// apenas das letras ASCII, sem alocar.
+func equalFoldASCII(value, lower string) bool {
+	if len(value) != len(lower) {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if isUpperByte(c) {
+			c += 'a' - 'A'
+		}
+		if c != lower[i] {
+			return false
+		}
+	}
+	return true
 }
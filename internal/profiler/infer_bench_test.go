@@ -0,0 +1,57 @@
+package profiler
+
+import "testing"
+
+// benchColumns imita uma exportação fiscal: cada coluna com seu cabeçalho e
+// valores típicos.
+var benchColumns = []struct {
+	header string
+	values []string
+}{
+	{"cpf_cliente", []string{"123.456.789-09", "12345678901", "987.654.321-00"}},
+	{"cnpj_fornecedor", []string{"12.345.678/0001-90", "12345678000190", "12.ABC.345/01DE-35"}},
+	{"email", []string{"contato@empresa.com.br", "fulano.tal@gmail.com", "sem-email"}},
+	{"dt_emissao", []string{"2024-05-01", "25/12/2023", "20231225"}},
+	{"criado_em", []string{"2024-05-01T13:45:00Z", "01/05/2024 13:45", "13:45:00"}},
+	{"valor_total", []string{"1.234,56", "R$ 10,00", "99,90"}},
+	{"quantidade", []string{"12", "1.234", "7"}},
+	{"descricao", []string{"Garrafa de Agua 500ml", "Parafuso sextavado", "CAIXA"}},
+	{"ativo", []string{"S", "N", "true"}},
+}
+
+func BenchmarkInferType(b *testing.B) {
+	registries := []struct {
+		name     string
+		registry *Registry
+	}{
+		{"Regex", NewRegistry(regexDetectors()...)},
+		{"Scanner", DefaultRegistry()},
+	}
+
+	for _, r := range registries {
+		b.Run(r.name, func(b *testing.B) {
+			inferrers := make([]*ColumnInferrer, len(benchColumns))
+			for i, column := range benchColumns {
+				inferrers[i] = r.registry.ForColumn(column.header)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j, column := range benchColumns {
+					for _, value := range column.values {
+						inferrers[j].Infer(value)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkColumnAccumulator_Add(b *testing.B) {
+	acc := NewColumnAccumulator("valor_total")
+	values := benchColumns[5].values
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		acc.Add(values[i%len(values)])
+	}
+}
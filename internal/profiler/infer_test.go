@@ -15,6 +15,16 @@ func TestInferType(t *testing.T) {
 		{"Inteiro Negativo", "-50", "temp", TypeInteger},
 		{"Float Ponto", "12.50", "valor", TypeFloat},
 		{"Float Virgula (BR)", "12,50", "preco", TypeFloat},
+		{"Float Milhar BR", "1.234,56", "valor", TypeFloat},
+		{"Float Milhar US", "1,234.56", "valor", TypeFloat},
+		{"Milhar Ambíguo (padrão pt-BR)", "1.234", "qtd", TypeInteger},
+		{"Negativo Contábil", "(150,00)", "saldo", TypeFloat},
+		{"Notação Científica", "1.5e-07", "fator", TypeFloat},
+		{"Moeda BRL", "R$ 1.234,56", "valor", TypeCurrencyBRL},
+		{"Moeda BRL Negativa", "-R$ 10,00", "estorno", TypeCurrencyBRL},
+		{"Percentual", "12,5%", "aliquota", TypePercentage},
+		{"IP não é Número", "192.168.0.1", "host", TypeString},
+		{"NaN não é Número", "NaN", "valor", TypeString},
 		{"Boolean True", "true", "ativo", TypeBoolean},
 		{"Boolean S (Sim)", "s", "flag", TypeBoolean},
 		{"String Comum", "Garrafa de Agua", "desc", TypeString},
@@ -24,6 +34,11 @@ func TestInferType(t *testing.T) {
 		{"Email", "contato@empresa.com.br", "email_contato", TypeEmail},
 		{"Placa Mercosul", "ABC1D23", "placa_veiculo", TypePlaca},
 		{"Placa Antiga", "ABC1234", "veiculo", TypePlaca},
+		{"Chassi (VIN)", "1M8GDM9AXKP042788", "chassi", TypeVIN},
+		{"17 Digitos não é VIN", "12345678901234567", "codigo", TypeInteger},
+		{"Chassi Válido sem Dica", "1M8GDM9AXKP042788", "codigo", TypeVIN},
+		{"Chassi com DV Errado (Header)", "1M8GDM9A1KP042788", "chassi", TypeVIN},
+		{"SKU de 17 Caracteres não é VIN", "ABCDEFGHJK1234567", "sku", TypeString},
 		{"Container ISO", "MSKU1234567", "container", TypeContainer},
 		{"Celular BR", "11 91234-5678", "tel", TypeMobile},
 		{"CEP com Traço", "01310-100", "end_cep", TypeCEP},
@@ -33,9 +48,22 @@ func TestInferType(t *testing.T) {
 		// --- 3. DATAS ---
 		{"Data BR", "25/12/2023", "data", TypeDate},
 		{"Data ISO", "2023-12-25", "dt_nasc", TypeDate},
+		{"Datetime ISO UTC", "2024-05-01T13:45:00Z", "evento", TypeDatetime},
+		{"Datetime ISO Offset", "2024-05-01T13:45:00.123-03:00", "evento", TypeDatetime},
+		{"Datetime ISO Espaço", "2024-05-01 13:45:00", "evento", TypeDatetime},
+		{"Datetime BR", "01/05/2024 13:45", "evento", TypeDatetime},
+		{"Hora do Dia", "13:45:00", "hora", TypeTime},
+		{"Hora Impossível", "25:61", "hora", TypeString},
+		{"Epoch Milissegundos (Header)", "1714571100000", "event_timestamp", TypeEpoch},
+		{"Epoch Segundos (Header)", "1714571100", "created_ts", TypeEpoch},
+		{"Epoch Sem Header", "1714571100", "id_pedido", TypeInteger},
+		{"Epoch Fora da Janela", "9999999999999", "timestamp", TypeInteger},
 
 		// --- 4. ZONA DE CONFLITO: 8 DÍGITOS ---
 		{"8 Digitos -> NCM (Header)", "12345678", "ncm_produto", TypeNCM},
+		{"CEST Mascarado sem Dica", "01.001.00", "obs", TypeCEST},
+		{"Decimal com 5 Inteiros não é CEST", "12345.67", "valor_frete", TypeFloat},
+		{"Decimal com 5 Casas não é CEST", "12.34567", "peso", TypeFloat},
 		{"8 Digitos -> RNTRC (Header)", "12345678", "rntrc_motorista", TypeRNTRC},
 		{"8 Digitos -> CEP (Header)", "12345678", "cep_origem", TypeCEP},
 		{"8 Digitos -> Data Compacta", "20231225", "dt_emissao", TypeDateCompact},
@@ -44,14 +72,35 @@ func TestInferType(t *testing.T) {
 		// --- 5. ZONA DE CONFLITO: 11 DÍGITOS ---
 		{"11 Digitos -> CPF (Header)", "12345678901", "cpf_motorista", TypeCPF},
 		{"11 Digitos -> Inteiro (Sem Contexto)", "12345678901", "id_transacao", TypeInteger},
+		{"11 Digitos -> PIS (Header)", "12030203094", "pis_pasep", TypePIS},
+		{"11 Digitos -> CNH (Header)", "12345678900", "cnh_condutor", TypeCNH},
+		{"11 Digitos -> RENAVAM (Header)", "00639798349", "renavam", TypeRENAVAM},
+		{"PIS Formatado", "120.30203.09-4", "doc", TypePIS},
+
+		// --- 5b. OUTROS DOCUMENTOS ---
+		{"RENAVAM Antigo 9 Digitos", "639798349", "renavam_veiculo", TypeRENAVAM},
+		{"Título de Eleitor (Header)", "102300000132", "titulo_eleitor", TypeTituloEleitor},
+		{"Título de Eleitor Formatado", "1023 0000 0132", "obs", TypeTituloEleitor},
+		{"CNS (Header)", "170123456780008", "cns_paciente", TypeCNS},
+		{"CNS Formatado", "170 1234 5678 0008", "obs", TypeCNS},
+		{"12 Dígitos Agrupados sem DV não é Título", "1234 5678 9012", "x", TypeString},
+		{"15 Dígitos Agrupados sem DV não é CNS", "170 1234 5678 0009", "protocolo", TypeString},
+		{"15 Digitos Sem Contexto", "170123456780008", "coluna_x", TypeInteger},
 
 		// --- 6. ZONA DE CONFLITO: EAN vs CNPJ ---
 		{"EAN (Header Produto)", "7891234567890", "ean_produto", TypeEAN},
 		{"EAN (Header SKU)", "7891234567890", "sku", TypeEAN},
+		{"EAN-8 (Header)", "96385074", "codigo_barras", TypeEAN},
 		{"CNPJ Limpo (Header Empresa)", "12345678000190", "cnpj_emitente", TypeCNPJ},
 
 		// TESTE CRÍTICO: CNPJ/EAN sem header deve cair para INTEGER para não estragar cálculo
 		{"CNPJ Limpo (Padrão Regex)", "12345678000190", "coluna_x", TypeInteger},
+
+		// --- 7. CNPJ ALFANUMÉRICO (2026+) ---
+		{"CNPJ Alfanumérico Formatado", "12.ABC.345/01DE-35", "doc", TypeCNPJ},
+		{"CNPJ Alfanumérico Limpo (DV válido)", "12ABC34501DE35", "coluna_x", TypeCNPJ},
+		{"CNPJ Alfanumérico Limpo (Header)", "12ABC34501DE36", "cnpj_fornecedor", TypeCNPJ},
+		{"Alfanumérico 14 sem DV nem Header", "12ABC34501DE36", "coluna_x", TypeString},
 	}
 
 	for _, tt := range tests {
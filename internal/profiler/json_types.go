@@ -0,0 +1,88 @@
+package profiler
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ValueKind é o tipo nativo de um valor JSON. Valores de CSV não têm tipo
+// nativo (KindUnknown) e são inferidos só pelo texto.
+type ValueKind uint8
+
+const (
+	KindUnknown ValueKind = iota
+	KindString
+	KindNumber
+	KindBool
+	KindNull
+	KindObject
+	KindArray
+)
+
+var valueKindNames = [...]string{"", "string", "number", "boolean", "null", "object", "array"}
+
+func (k ValueKind) String() string {
+	if int(k) < len(valueKindNames) {
+		return valueKindNames[k]
+	}
+	return ""
+}
+
+// addJSONNumber registra um número JSON. O literal já está no formato do
+// JSON (ponto decimal, sem milhar), então dispensa a convenção decimal da
+// coluna: é FLOAT se tiver fração ou expoente, INTEGER caso contrário.
+func (acc *ColumnAccumulator) addJSONNumber(value string, line int) {
+	kind := TypeInteger
+	if strings.ContainsAny(value, ".eE") {
+		kind = TypeFloat
+	}
+	acc.TypeCounts[kind]++
+	acc.examples.add(exampleKey{kind, exampleValid, KindNumber}, value, line)
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		acc.updateNumericStats(f, line)
+	}
+}
+
+// majorityKind retorna o tipo JSON mais frequente (KindUnknown sem valores
+// JSON). Empates ficam com o menor ValueKind, para o resultado não depender
+// da ordem do map.
+func majorityKind(counts map[ValueKind]int) ValueKind {
+	majority := KindUnknown
+	for _, kind := range slices.Sorted(maps.Keys(counts)) {
+		if counts[kind] > counts[majority] {
+			majority = kind
+		}
+	}
+	return majority
+}
+
+// jsonTypeCounts expõe a contagem por tipo JSON com os nomes do JSON.
+func jsonTypeCounts(counts map[ValueKind]int) map[string]int {
+	if len(counts) == 0 {
+		return nil
+	}
+	named := make(map[string]int, len(counts))
+	for kind, n := range counts {
+		named[kind.String()] += n
+	}
+	return named
+}
+
+// nativeMismatches conta os valores do tipo principal cujo tipo JSON difere
+// do majoritário ("123" numa coluna de números). Inválidos já descontam da
+// consistência e ficam de fora.
+func (s *exampleSampler) nativeMismatches(mainType DataType, majority ValueKind) int {
+	n := 0
+	for key, r := range s.byKey {
+		if key.dtype == mainType && key.kind != exampleInvalid && isNativeMismatch(key.native, majority) {
+			n += r.seen
+		}
+	}
+	return n
+}
+
+func isNativeMismatch(native, majority ValueKind) bool {
+	return native != KindUnknown && majority != KindUnknown && native != majority
+}
@@ -0,0 +1,93 @@
+package profiler
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestColumnAccumulator_AddTyped(t *testing.T) {
+	t.Run("Números JSON", func(t *testing.T) {
+		acc := NewColumnAccumulator("valor")
+		acc.AddTyped("1e+06", KindNumber, 1)
+		acc.AddTyped("1.5e-07", KindNumber, 2)
+		acc.AddTyped("1000", KindNumber, 3)
+		result := acc.Result()
+
+		// "1000" seria ambíguo em texto; como número JSON é um inteiro.
+		if result.TypeCounts[TypeFloat] != 2 || result.TypeCounts[TypeInteger] != 1 {
+			t.Errorf("Tipos: %v", result.TypeCounts)
+		}
+		if result.Stats[StatMax] != "1000000.00" {
+			t.Errorf("Máximo deveria ser 1e+06, recebeu %s", result.Stats[StatMax])
+		}
+		if result.DecimalSeparator != "" {
+			t.Errorf("Números JSON não definem convenção decimal, recebeu %q", result.DecimalSeparator)
+		}
+	})
+
+	t.Run("Booleano e null", func(t *testing.T) {
+		acc := NewColumnAccumulator("ativo")
+		acc.AddTyped("true", KindBool, 1)
+		acc.AddTyped("false", KindBool, 2)
+		acc.AddTyped("", KindNull, 3)
+		acc.AddMissing()
+		result := acc.Result()
+
+		if result.MainType != TypeBoolean || result.ConsistencyRatio != 1 {
+			t.Errorf("Esperava BOOLEAN consistente, recebeu %s (%v)", result.MainType, result.ConsistencyRatio)
+		}
+		if result.NullCount != 1 || result.MissingCount != 1 || result.BlankCount != 2 {
+			t.Errorf("null=%d ausentes=%d vazios=%d", result.NullCount, result.MissingCount, result.BlankCount)
+		}
+		if result.PresenceRatio != 0.75 {
+			t.Errorf("null conta como presente: presence=%v", result.PresenceRatio)
+		}
+	})
+
+	t.Run("Tipos JSON misturados", func(t *testing.T) {
+		acc := NewColumnAccumulator("codigo")
+		acc.AddTyped("123", KindNumber, 1)
+		acc.AddTyped("124", KindNumber, 2)
+		acc.AddTyped("125", KindString, 3)
+		acc.AddTyped("126", KindNumber, 4)
+		result := acc.Result()
+
+		if result.MainType != TypeInteger || result.ConsistencyRatio != 0.75 {
+			t.Errorf("Esperava INTEGER com consistência 0.75, recebeu %s (%v)", result.MainType, result.ConsistencyRatio)
+		}
+		if want := map[string]int{"number": 3, "string": 1}; !maps.Equal(result.JSONTypes, want) {
+			t.Errorf("JSONTypes: esperava %v, recebeu %v", want, result.JSONTypes)
+		}
+		if len(result.InvalidExamples) != 1 || result.InvalidExamples[0].Line != 3 ||
+			result.InvalidExamples[0].Reason != "string JSON em vez de number" {
+			t.Errorf("Exemplos: %+v", result.InvalidExamples)
+		}
+	})
+
+	t.Run("CSV sem tipo nativo", func(t *testing.T) {
+		acc := NewColumnAccumulator("codigo")
+		acc.AddAt("123", 1)
+		acc.AddAt("124", 2)
+		result := acc.Result()
+		if result.JSONTypes != nil || result.ConsistencyRatio != 1 {
+			t.Errorf("CSV não deveria ter tipos JSON: %v (%v)", result.JSONTypes, result.ConsistencyRatio)
+		}
+	})
+}
+
+func TestColumnAccumulator_MergeJSONTypes(t *testing.T) {
+	a, b := NewColumnAccumulator("codigo"), NewColumnAccumulator("codigo")
+	a.AddTyped("1", KindNumber, 1)
+	a.AddTyped("", KindNull, 2)
+	b.AddTyped("2", KindNumber, 3)
+	b.AddTyped("3", KindString, 4)
+	a.Merge(b)
+	result := a.Result()
+
+	if result.NullCount != 1 || result.JSONTypes["number"] != 2 || result.JSONTypes["string"] != 1 {
+		t.Errorf("Merge: null=%d tipos=%v", result.NullCount, result.JSONTypes)
+	}
+	if want := 2.0 / 3; result.ConsistencyRatio != want {
+		t.Errorf("Consistência: esperava %v, recebeu %v", want, result.ConsistencyRatio)
+	}
+}
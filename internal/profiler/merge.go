@@ -0,0 +1,106 @@
+package profiler
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// Merge incorpora outro acumulador da mesma coluna, criado com as mesmas
+// opções (tipicamente de outro worker). Contagens são somadas, os sketches
+// (t-digest, HyperLogLog, Space-Saving) são mesclados e as amostras de
+// reservatório são reamostradas na proporção dos valores vistos por cada
+// lado. other não deve ser usado depois.
+func (acc *ColumnAccumulator) Merge(other *ColumnAccumulator) {
+	if other == nil {
+		return
+	}
+	acc.TotalCount += other.TotalCount
+	acc.BlankCount += other.BlankCount
+	acc.MissingCount += other.MissingCount
+	acc.NullCount += other.NullCount
+	acc.repeatedCount += other.repeatedCount
+	acc.CountFilled += other.CountFilled
+	mergeCounts(acc.NullTokenCounts, other.NullTokenCounts)
+	mergeCounts(acc.nativeCounts, other.nativeCounts)
+	mergeCounts(acc.TypeCounts, other.TypeCounts)
+	mergeCounts(acc.validCounts, other.validCounts)
+	mergeCounts(acc.invalidCounts, other.invalidCounts)
+	for dtype, counts := range other.formatCounts {
+		if acc.formatCounts[dtype] == nil {
+			acc.formatCounts[dtype] = make(map[string]int)
+		}
+		mergeCounts(acc.formatCounts[dtype], counts)
+	}
+
+	if other.fiscalKeys != nil {
+		if acc.fiscalKeys == nil {
+			acc.fiscalKeys = newFiscalKeyTally()
+		}
+		acc.fiscalKeys.merge(other.fiscalKeys)
+	}
+	if other.gtins != nil {
+		if acc.gtins == nil {
+			acc.gtins = newGTINTally()
+		}
+		acc.gtins.merge(other.gtins)
+	}
+	acc.dates.merge(other.dates)
+	for dtype, tally := range other.temporal {
+		if acc.temporal[dtype] == nil {
+			acc.temporal[dtype] = newTemporalTally()
+		}
+		acc.temporal[dtype].merge(tally)
+	}
+
+	acc.numericSample = mergeReservoirs(acc.rng, acc.numericSample, acc.numeric.count,
+		other.numericSample, other.numeric.count, acc.sampleSize)
+	acc.numeric.merge(other.numeric)
+	acc.extremes.merge(other.extremes)
+
+	acc.distinct.merge(other.distinct)
+	acc.frequent.merge(other.frequent)
+	acc.shapes.merge(other.shapes)
+	acc.text.merge(other.text)
+	acc.examples.merge(other.examples)
+
+	// Os números ambíguos dos dois lados seguem pendentes: Result os resolve
+	// uma única vez, com a convenção combinada de todos os acumuladores.
+	acc.decimals.merge(other.decimals)
+	acc.pendingNumbers = append(acc.pendingNumbers, other.pendingNumbers...)
+}
+
+func mergeCounts[K comparable](dst, src map[K]int) {
+	for k, v := range src {
+		dst[k] += v
+	}
+}
+
+// mergeReservoirs combina duas amostras de reservatório de tamanho size,
+// vindas de seenA e seenB valores: cada vaga é sorteada de um dos lados com
+// probabilidade proporcional aos valores ainda não representados dele.
+func mergeReservoirs[T any](rng *rand.Rand, a []T, seenA int, b []T, seenB int, size int) []T {
+	if seenA+seenB <= size {
+		return append(a, b...)
+	}
+
+	a, b = slices.Clone(a), slices.Clone(b)
+	merged := make([]T, 0, size)
+	for len(merged) < size && len(a)+len(b) > 0 {
+		fromA := len(b) == 0 || (len(a) > 0 && rng.IntN(seenA+seenB) < seenA)
+		if fromA {
+			a, merged = takeRandom(rng, a, merged)
+			seenA--
+		} else {
+			b, merged = takeRandom(rng, b, merged)
+			seenB--
+		}
+	}
+	return merged
+}
+
+func takeRandom[T any](rng *rand.Rand, from, to []T) ([]T, []T) {
+	i := rng.IntN(len(from))
+	to = append(to, from[i])
+	from[i] = from[len(from)-1]
+	return from[:len(from)-1], to
+}
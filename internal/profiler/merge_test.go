@@ -0,0 +1,180 @@
+package profiler
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// mergeTestValues mistura inteiros, decimais ambíguos, textos, nulos e um
+// outlier para exercitar todos os sketches do acumulador.
+func mergeTestValues() []string {
+	var values []string
+	for i := range 3000 {
+		switch {
+		case i%97 == 0:
+			values = append(values, "N/A")
+		case i%53 == 0:
+			values = append(values, "sem valor")
+		case i%31 == 0:
+			values = append(values, "1.234")
+		default:
+			values = append(values, fmt.Sprintf("%d,%02d", 100+i%400, i%100))
+		}
+	}
+	values[1500] = "999999,00"
+	return values
+}
+
+func TestColumnAccumulator_Merge(t *testing.T) {
+	values := mergeTestValues()
+
+	single := NewColumnAccumulator("valor_frete")
+	for i, v := range values {
+		single.AddAt(v, i+2)
+	}
+	expected := single.Result()
+
+	parts := []*ColumnAccumulator{
+		NewColumnAccumulator("valor_frete"),
+		NewColumnAccumulator("valor_frete"),
+		NewColumnAccumulator("valor_frete"),
+	}
+	for i, v := range values {
+		parts[i%len(parts)].AddAt(v, i+2)
+	}
+	merged := parts[0]
+	merged.Merge(parts[1])
+	merged.Merge(parts[2])
+	got := merged.Result()
+
+	checks := []struct {
+		field         string
+		got, expected any
+	}{
+		{"MainType", got.MainType, expected.MainType},
+		{"CountFilled", got.CountFilled, expected.CountFilled},
+		{"BlankCount", got.BlankCount, expected.BlankCount},
+		{"NullTokenCounts", got.NullTokenCounts, expected.NullTokenCounts},
+		{"TypeCounts", got.TypeCounts, expected.TypeCounts},
+		{"DecimalSeparator", got.DecimalSeparator, expected.DecimalSeparator},
+		{"DistinctEstimate", got.DistinctEstimate, expected.DistinctEstimate},
+		// O Space-Saving transborda (400 distintos): só o topo é garantido.
+		{"TopValues[:2]", got.TopValues[:2], expected.TopValues[:2]},
+		{"TopPatterns", patternCounts(got.TopPatterns), patternCounts(expected.TopPatterns)},
+		{"TextProfile", got.TextProfile, expected.TextProfile},
+		{"SLA", got.SLA, expected.SLA},
+		{"Stats[min]", got.Stats[StatMin], expected.Stats[StatMin]},
+		{"Stats[max]", got.Stats[StatMax], expected.Stats[StatMax]},
+		{"Stats[sum]", got.Stats[StatSum], expected.Stats[StatSum]},
+		{"Stats[std_dev]", got.Stats[StatStdDev], expected.Stats[StatStdDev]},
+		{"len(InvalidExamples)", len(got.InvalidExamples), len(expected.InvalidExamples)},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.expected) {
+			t.Errorf("%s = %v; esperava %v", c.field, c.got, c.expected)
+		}
+	}
+}
+
+func TestColumnAccumulator_MergeResolvesAmbiguousOnce(t *testing.T) {
+	// O primeiro lado só vê vírgula decimal; a coluna inteira usa ponto. O
+	// "1.234" precisa ser lido como 1,234 independentemente da divisão.
+	first := []string{"1,5", "1.234"}
+	second := []string{"2.5", "3.5", "4.5"}
+
+	single := NewColumnAccumulator("peso")
+	for _, v := range append(slices.Clone(first), second...) {
+		single.Add(v)
+	}
+	expected := single.Result()
+
+	a, b := NewColumnAccumulator("peso"), NewColumnAccumulator("peso")
+	for _, v := range first {
+		a.Add(v)
+	}
+	for _, v := range second {
+		b.Add(v)
+	}
+	a.Merge(b)
+	got := a.Result()
+
+	if got.DecimalSeparator != "." || got.TypeCounts[TypeFloat] != 5 || got.TypeCounts[TypeInteger] != 0 {
+		t.Errorf("Esperava 5 FLOAT com ponto decimal, recebeu %v (%q)", got.TypeCounts, got.DecimalSeparator)
+	}
+	if !maps.Equal(got.TypeCounts, expected.TypeCounts) || got.Stats[StatMax] != expected.Stats[StatMax] ||
+		got.Stats[StatSum] != expected.Stats[StatSum] {
+		t.Errorf("Mesclado difere do sequencial: %v %v; esperava %v %v",
+			got.TypeCounts, got.Stats, expected.TypeCounts, expected.Stats)
+	}
+}
+
+func patternCounts(patterns []PatternCount) map[string]int {
+	counts := make(map[string]int, len(patterns))
+	for _, p := range patterns {
+		counts[p.Pattern] = p.Count
+	}
+	return counts
+}
+
+func TestSpaceSaving_Merge(t *testing.T) {
+	a, b := newSpaceSaving(3), newSpaceSaving(3)
+	for _, v := range []string{"SP", "SP", "SP", "RJ", "RJ", "MG"} {
+		a.add(v)
+	}
+	for _, v := range []string{"SP", "BA", "BA"} {
+		b.add(v)
+	}
+	a.merge(b)
+
+	// a está cheio com mínimo 1: BA, ausente dele, herda 1 como contagem e
+	// erro. b tem espaço livre, então RJ e MG não ganham nada.
+	expected := []ValueCount{
+		{Value: "SP", Count: 4},
+		{Value: "BA", Count: 3, Error: 1},
+		{Value: "RJ", Count: 2},
+	}
+	if got := a.top(3); !reflect.DeepEqual(got, expected) {
+		t.Errorf("top = %+v; esperava %+v", got, expected)
+	}
+}
+
+func TestProfileAsync_Workers(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	headers := []string{"id", "uf", "valor"}
+	ufs := []string{"SP", "RJ", "MG", "BA", "PR"}
+
+	run := func(workers int) ProfilerResult {
+		dataChan := make(chan StreamData, 100)
+		go func() {
+			defer close(dataChan)
+			for i := range 5000 {
+				row := []string{fmt.Sprint(i), ufs[i%len(ufs)], fmt.Sprintf("%d,50", i%50)}
+				dataChan <- StreamData{Row: row, LineNumber: i + 2}
+			}
+		}()
+		return ProfileAsync(logger, headers, dataChan, "pedidos.csv", WithWorkers(workers))
+	}
+
+	sequential, parallel := run(1), run(4)
+	if parallel.TotalMaxRows != sequential.TotalMaxRows {
+		t.Fatalf("TotalMaxRows = %d; esperava %d", parallel.TotalMaxRows, sequential.TotalMaxRows)
+	}
+	for i, want := range sequential.Columns {
+		got := parallel.Columns[i]
+		if got.MainType != want.MainType || got.CountFilled != want.CountFilled ||
+			!maps.Equal(got.TypeCounts, want.TypeCounts) || got.DistinctEstimate != want.DistinctEstimate ||
+			got.IsCandidateKey != want.IsCandidateKey || !reflect.DeepEqual(got.TopValues, want.TopValues) {
+			t.Errorf("coluna %s difere entre 1 e 4 workers:\n%+v\n%+v", want.Name, got, want)
+		}
+		for _, key := range []StatKey{StatMin, StatMax, StatSum, StatAverage} {
+			if got.Stats[key] != want.Stats[key] {
+				t.Errorf("coluna %s: Stats[%s] = %s; esperava %s", want.Name, key, got.Stats[key], want.Stats[key])
+			}
+		}
+	}
+}
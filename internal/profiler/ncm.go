@@ -0,0 +1,94 @@
+package profiler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NCMTable é o conjunto de códigos NCM vigentes (8 dígitos, sem máscara).
+type NCMTable map[string]struct{}
+
+// Contains informa se o código, com ou sem máscara, consta da tabela.
+func (t NCMTable) Contains(code string) bool {
+	_, ok := t[strings.ReplaceAll(code, ".", "")]
+	return ok
+}
+
+// LoadNCMTable lê a tabela NCM/TIPI de um arquivo local. Aceita o JSON
+// publicado pelo Siscomex ({"Nomenclaturas": [{"Codigo": "0101.21.00"}]}) ou
+// texto/CSV com o código na primeira coluna; linhas de capítulos e posições
+// (menos de 8 dígitos) são ignoradas.
+func LoadNCMTable(path string) (NCMTable, error) {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return loadNCMJSON(path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	table := make(NCMTable)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		field, _, _ := strings.Cut(scanner.Text(), ";")
+		field, _, _ = strings.Cut(field, ",")
+		field, _, _ = strings.Cut(field, "\t")
+		table.add(strings.Trim(strings.TrimSpace(field), `"`))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro lendo tabela NCM %s: %w", path, err)
+	}
+	if len(table) == 0 {
+		return nil, fmt.Errorf("tabela NCM %s não contém códigos de 8 dígitos", path)
+	}
+	return table, nil
+}
+
+func loadNCMJSON(path string) (NCMTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Nomenclaturas []struct {
+			Codigo string `json:"Codigo"`
+		} `json:"Nomenclaturas"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("erro lendo tabela NCM %s: %w", path, err)
+	}
+
+	table := make(NCMTable)
+	for _, n := range file.Nomenclaturas {
+		table.add(n.Codigo)
+	}
+	if len(table) == 0 {
+		return nil, fmt.Errorf("tabela NCM %s não contém códigos de 8 dígitos", path)
+	}
+	return table, nil
+}
+
+func (t NCMTable) add(code string) {
+	code = strings.ReplaceAll(code, ".", "")
+	if len(code) == 8 && isDigits(code) {
+		t[code] = struct{}{}
+	}
+}
+
+// IsValidNCM confere a estrutura do NCM: 8 dígitos e capítulo (dois
+// primeiros) de 01 a 97, exceto o 77, reservado pelo Sistema Harmonizado.
+func IsValidNCM(value string) bool {
+	digits := onlyDigits(value)
+	if len(digits) != 8 {
+		return false
+	}
+	chapter := digits[0]*10 + digits[1]
+	return chapter >= 1 && chapter <= 97 && chapter != 77
+}
@@ -0,0 +1,86 @@
+package profiler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadNCMTable(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"tipi.csv": "Código;Descrição\n01;Animais vivos\n01.01;Cavalos\n0101.21.00;Reprodutores de raça pura\n\"0101.29.00\";Outros\n",
+		"ncm.json": `{"Data_Ultima_Atualizacao_NCM": "Vigente", "Nomenclaturas": [
+			{"Codigo": "01", "Descricao": "Animais vivos"},
+			{"Codigo": "0101.21.00", "Descricao": "Reprodutores de raça pura"},
+			{"Codigo": "0101.29.00", "Descricao": "Outros"}]}`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			table, err := LoadNCMTable(path)
+			if err != nil {
+				t.Fatalf("Erro inesperado: %v", err)
+			}
+			if len(table) != 2 {
+				t.Errorf("Esperava 2 códigos, recebeu %d", len(table))
+			}
+			if !table.Contains("0101.21.00") || !table.Contains("01012900") {
+				t.Errorf("Códigos esperados ausentes: %v", table)
+			}
+		})
+	}
+
+	t.Run("Arquivo sem códigos", func(t *testing.T) {
+		path := filepath.Join(dir, "vazio.csv")
+		if err := os.WriteFile(path, []byte("Código;Descrição\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadNCMTable(path); err == nil {
+			t.Error("Esperava erro para tabela sem códigos")
+		}
+	})
+}
+
+func TestNCMTableValidation(t *testing.T) {
+	table := NCMTable{"01012100": {}, "01012900": {}}
+	values := []string{"01012100", "01012900", "01019999", "77010000"}
+
+	acc := NewColumnAccumulator("ncm_produto", WithNCMTable(table))
+	for _, v := range values {
+		acc.Add(v)
+	}
+	streaming := acc.Result()
+	sync := AnalyzeColumn(Column{Name: "ncm_produto", Values: values}, WithNCMTable(table))
+
+	for name, result := range map[string]ColumnResult{"streaming": streaming, "sync": sync} {
+		if result.MainType != TypeNCM {
+			t.Fatalf("[%s] Esperava MainType NCM, recebeu %s", name, result.MainType)
+		}
+		if result.ValidCount != 2 || result.InvalidCount != 2 {
+			t.Errorf("[%s] Esperava 2 válidos e 2 inválidos, recebeu %d/%d", name, result.ValidCount, result.InvalidCount)
+		}
+		if result.ConsistencyRatio != 0.5 {
+			t.Errorf("[%s] Esperava consistência 0.5, recebeu %f", name, result.ConsistencyRatio)
+		}
+		if result.SLA != SlaCritical {
+			t.Errorf("[%s] Códigos fora da tabela deveriam derrubar o SLA, recebeu %s (%s)", name, result.SLA, result.SlaReason)
+		}
+	}
+
+	t.Run("Sem tabela só a estrutura é validada", func(t *testing.T) {
+		result := AnalyzeColumn(Column{Name: "ncm_produto", Values: values})
+		if result.InvalidCount != 1 {
+			t.Errorf("Esperava 1 inválido (capítulo 77), recebeu %d", result.InvalidCount)
+		}
+		if result.SLA != SlaCritical {
+			t.Errorf("Esperava CRITICAL com 25%% inválidos, recebeu %s", result.SLA)
+		}
+	})
+}
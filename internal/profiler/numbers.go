@@ -0,0 +1,297 @@
+package profiler
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DecimalSeparator indica a convenção numérica de uma coluna: pt-BR usa
+// vírgula como decimal (1.234,56) e en-US usa ponto (1,234.56).
+type DecimalSeparator byte
+
+const (
+	DecimalComma DecimalSeparator = ','
+	DecimalPoint DecimalSeparator = '.'
+)
+
+func (d DecimalSeparator) String() string {
+	if d == 0 {
+		return ""
+	}
+	return string(rune(d))
+}
+
+// Limite de valores ambíguos (ex.: "1.234") guardados por acumulador até
+// Result resolvê-los pela convenção decimal da coluna inteira.
+const pendingNumbersLimit = 1000
+
+type pendingNumber struct {
+	value  string
+	native ValueKind
+	line   int
+}
+
+type parsedNumber struct {
+	value float64
+	kind  DataType
+	// evidence é o separador decimal que o valor revela sem ambiguidade;
+	// zero quando o valor não tem separador ou admite as duas leituras.
+	evidence  DecimalSeparator
+	ambiguous bool
+	// thousands é o separador de milhar que splitNumber deixou na parte
+	// inteira.
+	thousands byte
+}
+
+// ParseNumber converte números em formato pt-BR ou en-US, incluindo moeda
+// (R$ 1.234,56), percentuais (12,5%) e negativos contábeis ((150,00)).
+// Valores ambíguos como "1.234" são lidos conforme o separador decimal
+// informado.
+func ParseNumber(value string, decimal DecimalSeparator) (float64, bool) {
+	number, ok := scanNumber(value, decimal)
+	return number.value, ok
+}
+
+func scanNumber(value string, decimal DecimalSeparator) (parsedNumber, bool) {
+	var number parsedNumber
+	s := strings.TrimSpace(value)
+
+	negative := false
+	if len(s) > 2 && s[0] == '(' && s[len(s)-1] == ')' {
+		negative = true
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	s, negative = trimSign(s, negative)
+
+	currency := false
+	if strings.HasPrefix(s, "R$") {
+		currency = true
+		s = strings.TrimSpace(s[2:])
+		s, negative = trimSign(s, negative)
+	}
+
+	percent := false
+	if strings.HasSuffix(s, "%") {
+		percent = true
+		s = strings.TrimSpace(s[:len(s)-1])
+	}
+
+	if s == "" || (currency && percent) {
+		return number, false
+	}
+
+	intPart, fracPart, ok := splitNumber(s, decimal, &number)
+	if !ok {
+		return number, false
+	}
+
+	// Monta os dígitos num buffer na pilha para não alocar por valor.
+	var buf [32]byte
+	digits := buf[:0]
+	for i := 0; i < len(intPart); i++ {
+		if intPart[i] != number.thousands {
+			digits = append(digits, intPart[i])
+		}
+	}
+	if fracPart != "" {
+		digits = append(append(digits, '.'), fracPart...)
+	}
+	if len(digits) == 0 {
+		return number, false
+	}
+	parsed, err := strconv.ParseFloat(string(digits), 64)
+	if err != nil {
+		return number, false
+	}
+	if negative {
+		parsed = -parsed
+	}
+	number.value = parsed
+
+	switch {
+	case currency:
+		number.kind = TypeCurrencyBRL
+	case percent:
+		number.kind = TypePercentage
+	case fracPart != "" || strings.ContainsAny(s, "eE"):
+		number.kind = TypeFloat
+	default:
+		number.kind = TypeInteger
+	}
+	return number, true
+}
+
+func trimSign(s string, negative bool) (string, bool) {
+	if strings.HasPrefix(s, "-") {
+		return strings.TrimSpace(s[1:]), !negative
+	}
+	if strings.HasPrefix(s, "+") {
+		return strings.TrimSpace(s[1:]), negative
+	}
+	return s, negative
+}
+
+// splitNumber separa parte inteira e fracionária, registrando em number a
+// evidência de convenção encontrada e o separador de milhar a descartar.
+func splitNumber(s string, decimal DecimalSeparator, number *parsedNumber) (string, string, bool) {
+	if strings.ContainsAny(s, "eE") {
+		if strings.Contains(s, ",") {
+			return "", "", false
+		}
+		if !isScientific(s) {
+			return "", "", false
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return "", "", false
+		}
+		return s, "", true
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && c != '.' && c != ',' {
+			return "", "", false
+		}
+	}
+
+	dots := strings.Count(s, ".")
+	commas := strings.Count(s, ",")
+
+	switch {
+	case dots == 0 && commas == 0:
+		return s, "", true
+
+	case dots > 0 && commas > 0:
+		lastDot := strings.LastIndexByte(s, '.')
+		lastComma := strings.LastIndexByte(s, ',')
+		sep, thousands := DecimalComma, byte('.')
+		if lastDot > lastComma {
+			sep, thousands = DecimalPoint, ','
+		}
+		idx := strings.LastIndexByte(s, byte(sep))
+		if strings.Count(s, string(rune(sep))) > 1 {
+			return "", "", false
+		}
+		if !validGroups(s[:idx], thousands) {
+			return "", "", false
+		}
+		number.evidence = sep
+		number.thousands = thousands
+		return s[:idx], s[idx+1:], isDigits(s[idx+1:])
+
+	case dots > 1 || commas > 1:
+		// Separador repetido só pode ser de milhar.
+		thousands, sep := byte('.'), DecimalComma
+		if commas > 1 {
+			thousands, sep = ',', DecimalPoint
+		}
+		if !validGroups(s, thousands) {
+			return "", "", false
+		}
+		number.evidence = sep
+		number.thousands = thousands
+		return s, "", true
+	}
+
+	sep := DecimalPoint
+	if commas == 1 {
+		sep = DecimalComma
+	}
+	idx := strings.IndexByte(s, byte(sep))
+	intPart, fracPart := s[:idx], s[idx+1:]
+
+	if len(fracPart) == 3 && len(intPart) >= 1 && len(intPart) <= 3 && intPart[0] != '0' {
+		number.ambiguous = true
+		if sep != decimal {
+			number.thousands = byte(sep)
+			return s, "", true
+		}
+		return intPart, fracPart, true
+	}
+
+	if intPart == "" && fracPart == "" {
+		return "", "", false
+	}
+	number.evidence = sep
+	return intPart, fracPart, true
+}
+
+// validGroups confere se s tem dígitos em grupos de três separados por
+// thousands (o primeiro grupo com 1 a 3).
+func validGroups(s string, thousands byte) bool {
+	groups, size := 0, 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && s[i] != thousands {
+			if !isDigitByte(s[i]) {
+				return false
+			}
+			size++
+			continue
+		}
+		if groups == 0 && (size == 0 || size > 3 && i < len(s)) || groups > 0 && size != 3 {
+			return false
+		}
+		groups++
+		size = 0
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isScientific(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && c != '.' && c != 'e' && c != 'E' && c != '-' && c != '+' {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumericType(t DataType) bool {
+	switch t {
+	case TypeInteger, TypeFloat, TypeCurrencyBRL, TypePercentage:
+		return true
+	}
+	return false
+}
+
+// decimalTally escolhe a convenção decimal da coluna pela maioria das
+// evidências, com pt-BR como padrão em caso de empate ou ausência.
+type decimalTally struct {
+	comma int
+	point int
+}
+
+func (dt *decimalTally) add(evidence DecimalSeparator) {
+	switch evidence {
+	case DecimalComma:
+		dt.comma++
+	case DecimalPoint:
+		dt.point++
+	}
+}
+
+func (dt *decimalTally) merge(other decimalTally) {
+	dt.comma += other.comma
+	dt.point += other.point
+}
+
+func (dt *decimalTally) separator() DecimalSeparator {
+	if dt.point > dt.comma {
+		return DecimalPoint
+	}
+	return DecimalComma
+}
+
+func (dt *decimalTally) decided() bool {
+	return dt.comma+dt.point > 0
+}
@@ -0,0 +1,104 @@
+package profiler
+
+import "testing"
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		decimal  DecimalSeparator
+		expected float64
+		ok       bool
+	}{
+		{"Inteiro", "42", DecimalComma, 42, true},
+		{"Decimal BR", "12,50", DecimalComma, 12.5, true},
+		{"Milhar e Decimal BR", "1.234,56", DecimalComma, 1234.56, true},
+		{"Milhar e Decimal US", "1,234.56", DecimalComma, 1234.56, true},
+		{"Vários Milhares BR", "12.345.678", DecimalComma, 12345678, true},
+		{"Ambíguo em Coluna BR", "1.234", DecimalComma, 1234, true},
+		{"Ambíguo em Coluna US", "1.234", DecimalPoint, 1.234, true},
+		{"Vírgula Ambígua em Coluna US", "1,234", DecimalPoint, 1234, true},
+		{"Zero à Esquerda Não é Milhar", "0.123", DecimalComma, 0.123, true},
+		{"Moeda", "R$ 1.234,56", DecimalComma, 1234.56, true},
+		{"Moeda Negativa Após Símbolo", "R$ -10,00", DecimalComma, -10, true},
+		{"Negativo Contábil", "(150,00)", DecimalComma, -150, true},
+		{"Moeda Contábil", "(R$ 150,00)", DecimalComma, -150, true},
+		{"Percentual", "12,5%", DecimalComma, 12.5, true},
+		{"Científico", "1e+06", DecimalComma, 1000000, true},
+		{"Grupo de Milhar Inválido", "1.23.456", DecimalComma, 0, false},
+		{"Texto", "abc", DecimalComma, 0, false},
+		{"Moeda e Percentual", "R$ 10%", DecimalComma, 0, false},
+		{"Infinito", "Inf", DecimalComma, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseNumber(tt.value, tt.decimal)
+			if ok != tt.ok {
+				t.Fatalf("ParseNumber(%q) ok = %v; esperava %v", tt.value, ok, tt.ok)
+			}
+			if ok && got != tt.expected {
+				t.Errorf("ParseNumber(%q) = %v; esperava %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDecimalConventionDetection(t *testing.T) {
+	t.Run("Coluna pt-BR lê 1.234 como milhar", func(t *testing.T) {
+		acc := NewColumnAccumulator("valor")
+		acc.Add("1.234")
+		acc.Add("1.234,50")
+		acc.Add("10,00")
+
+		result := acc.Result()
+
+		if result.DecimalSeparator != "," {
+			t.Errorf("Esperava separador decimal ',', recebeu %q", result.DecimalSeparator)
+		}
+		checkStatsMap(t, result.Stats, map[StatKey]string{StatSum: "2478.50"})
+	})
+
+	t.Run("Coluna en-US lê 1.234 como decimal mesmo antes da evidência", func(t *testing.T) {
+		acc := NewColumnAccumulator("amount")
+		acc.Add("1.234")
+		acc.Add("1,234.50")
+		acc.Add("10.00")
+
+		result := acc.Result()
+
+		if result.DecimalSeparator != "." {
+			t.Errorf("Esperava separador decimal '.', recebeu %q", result.DecimalSeparator)
+		}
+		if result.MainType != TypeFloat {
+			t.Errorf("Esperava FLOAT, recebeu %s (%v)", result.MainType, result.TypeCounts)
+		}
+		checkStatsMap(t, result.Stats, map[StatKey]string{StatSum: "1245.73"})
+
+		sync := AnalyzeColumn(Column{Name: "amount", Values: []string{"1.234", "1,234.50", "10.00"}})
+		checkStatsMap(t, sync.Stats, map[StatKey]string{StatSum: "1245.73"})
+	})
+
+	t.Run("Moeda e percentual alimentam estatísticas", func(t *testing.T) {
+		acc := NewColumnAccumulator("frete")
+		acc.Add("R$ 1.000,00")
+		acc.Add("R$ 500,50")
+		acc.Add("(R$ 100,50)")
+
+		result := acc.Result()
+
+		if result.MainType != TypeCurrencyBRL {
+			t.Fatalf("Esperava CURRENCY_BRL, recebeu %s", result.MainType)
+		}
+		checkStatsMap(t, result.Stats, map[StatKey]string{StatMin: "-100.50", StatSum: "1400.00"})
+		if result.Histogram == nil {
+			t.Error("Histograma deveria ser gerado para moeda")
+		}
+
+		pct := AnalyzeColumn(Column{Name: "aliquota", Values: []string{"12,5%", "7%"}})
+		if pct.MainType != TypePercentage {
+			t.Fatalf("Esperava PERCENTAGE, recebeu %s", pct.MainType)
+		}
+		checkStatsMap(t, pct.Stats, map[StatKey]string{StatMax: "12.50"})
+	})
+}
@@ -0,0 +1,132 @@
+package profiler
+
+import (
+	"runtime"
+	"strings"
+)
+
+// DefaultNullTokens lista os marcadores de ausência mais comuns em exportações
+// de Excel/ERPs brasileiros. A comparação ignora maiúsculas/minúsculas.
+var DefaultNullTokens = []string{
+	"NULL", "(null)", "NIL", "NONE", "NA", "N/A", "N/D", "NAN",
+	"-", "--", "?",
+	"#N/D", "#N/A", "#VALOR!", "#REF!", "#DIV/0!", "#NOME?", "#NÚM!",
+}
+
+// Config reúne os parâmetros ajustáveis do profiling.
+type Config struct {
+	nullTokens   map[string]struct{}
+	maxTokenSize int
+	ncmTable     NCMTable
+	hllPrecision uint8
+	topK         int
+	topPatterns  int
+	// outlierSLA liga a checagem de maxOutlierRatio no SLA.
+	outlierSLA      bool
+	maxOutlierRatio float64
+	workers         int
+}
+
+// Option configura o profiling (ProfileAsync, Profile, AnalyzeColumn e
+// NewColumnAccumulator).
+type Option func(*Config)
+
+// WithNullTokens substitui a lista padrão de marcadores de nulo. Sem tokens,
+// apenas valores em branco contam como vazios.
+func WithNullTokens(tokens ...string) Option {
+	return func(c *Config) {
+		c.setNullTokens(tokens)
+	}
+}
+
+// WithNCMTable valida colunas NCM contra a tabela informada (ver
+// LoadNCMTable). Códigos ausentes contam como inconsistência no SLA.
+func WithNCMTable(table NCMTable) Option {
+	return func(c *Config) {
+		c.ncmTable = table
+	}
+}
+
+// WithHLLPrecision ajusta a precisão (4 a 18) do HyperLogLog usado na
+// contagem de distintos: cada ponto a mais dobra a memória por coluna e
+// reduz o erro em ~30%.
+func WithHLLPrecision(precision uint8) Option {
+	return func(c *Config) {
+		c.hllPrecision = precision
+	}
+}
+
+// WithTopK define quantos valores mais frequentes cada coluna reporta
+// (padrão 10; zero desativa).
+func WithTopK(n int) Option {
+	return func(c *Config) {
+		c.topK = max(n, 0)
+	}
+}
+
+// WithTopPatterns define quantos formatos de valor cada coluna reporta em
+// TopPatterns (padrão 10; zero desativa, e o motivo do SLA deixa de citar o
+// formato minoritário). Independe de WithTopK.
+func WithTopPatterns(n int) Option {
+	return func(c *Config) {
+		c.topPatterns = max(n, 0)
+	}
+}
+
+// WithOutlierSLA faz colunas numéricas com mais outliers que maxRatio (ex.:
+// 0.01 para 1%) receberem SLA WARNING.
+func WithOutlierSLA(maxRatio float64) Option {
+	return func(c *Config) {
+		c.outlierSLA = true
+		c.maxOutlierRatio = max(maxRatio, 0)
+	}
+}
+
+// WithWorkers define quantos workers o ProfileAsync usa (padrão: o número
+// de CPUs disponíveis).
+func WithWorkers(n int) Option {
+	return func(c *Config) {
+		c.workers = max(n, 1)
+	}
+}
+
+func newConfig(opts []Option) *Config {
+	cfg := &Config{hllPrecision: hllDefaultPrecision, topK: defaultTopK, topPatterns: defaultTopPatterns, workers: runtime.GOMAXPROCS(0)}
+	cfg.setNullTokens(DefaultNullTokens)
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+func (c *Config) setNullTokens(tokens []string) {
+	c.nullTokens = make(map[string]struct{}, len(tokens))
+	c.maxTokenSize = 0
+	for _, token := range tokens {
+		token = strings.ToUpper(strings.TrimSpace(token))
+		if token == "" {
+			continue
+		}
+		c.nullTokens[token] = struct{}{}
+		c.maxTokenSize = max(c.maxTokenSize, len(token))
+	}
+}
+
+// isNullToken espera o valor já sem espaços nas pontas.
+func (c *Config) isNullToken(value string) bool {
+	if len(value) > c.maxTokenSize {
+		return false
+	}
+	_, ok := c.nullTokens[strings.ToUpper(value)]
+	return ok
+}
+
+// validate aplica a validação do tipo (dígito verificador ou estrutura) e,
+// para NCM, a tabela de referência configurada.
+func (c *Config) validate(t DataType, value string) (checked bool, valid bool) {
+	checked, valid = validateChecksum(t, value)
+	if t == TypeNCM && c.ncmTable != nil {
+		return true, valid && c.ncmTable.Contains(value)
+	}
+	return checked, valid
+}
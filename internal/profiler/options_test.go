@@ -0,0 +1,62 @@
+package profiler
+
+import "testing"
+
+func TestNullTokens(t *testing.T) {
+	t.Run("Lista padrão conta marcadores como vazios", func(t *testing.T) {
+		acc := NewColumnAccumulator("Quantidade")
+		for _, v := range []string{"10", "20", "NULL", "null", "N/A", "-", "None", "#N/D", "30"} {
+			acc.Add(v)
+		}
+
+		result := acc.Result()
+
+		if result.BlankCount != 6 {
+			t.Errorf("Esperava 6 vazios, recebeu %d", result.BlankCount)
+		}
+		if result.TypeCounts[TypeString] != 0 {
+			t.Errorf("Marcadores de nulo não deveriam virar STRING: %v", result.TypeCounts)
+		}
+		if result.ConsistencyRatio != 1.0 {
+			t.Errorf("Esperava consistência 1.0, recebeu %f", result.ConsistencyRatio)
+		}
+
+		expected := map[string]int{"NULL": 1, "null": 1, "N/A": 1, "-": 1, "None": 1, "#N/D": 1}
+		for token, count := range expected {
+			if result.NullTokenCounts[token] != count {
+				t.Errorf("NullTokenCounts[%q]: esperava %d, recebeu %d", token, count, result.NullTokenCounts[token])
+			}
+		}
+	})
+
+	t.Run("Lista customizada substitui a padrão", func(t *testing.T) {
+		values := []string{"10", "NULL", "Não Informado", "não informado"}
+
+		acc := NewColumnAccumulator("Quantidade", WithNullTokens("Não Informado"))
+		for _, v := range values {
+			acc.Add(v)
+		}
+		result := acc.Result()
+
+		if result.BlankCount != 2 {
+			t.Errorf("Esperava 2 vazios, recebeu %d", result.BlankCount)
+		}
+		if result.TypeCounts[TypeString] != 1 {
+			t.Errorf("NULL deveria ser STRING com a lista customizada: %v", result.TypeCounts)
+		}
+
+		sync := AnalyzeColumn(Column{Name: "Quantidade", Values: values}, WithNullTokens("Não Informado"))
+		if sync.BlankCount != result.BlankCount || sync.NullTokenCounts["Não Informado"] != 1 {
+			t.Errorf("AnalyzeColumn divergiu: blank=%d tokens=%v", sync.BlankCount, sync.NullTokenCounts)
+		}
+	})
+
+	t.Run("Lista vazia desativa a detecção", func(t *testing.T) {
+		acc := NewColumnAccumulator("Obs", WithNullTokens())
+		acc.Add("NULL")
+
+		if result := acc.Result(); result.BlankCount != 0 {
+			t.Errorf("Esperava 0 vazios, recebeu %d", result.BlankCount)
+		}
+	})
+}
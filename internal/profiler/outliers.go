@@ -0,0 +1,197 @@
+package profiler
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Cercas de Tukey (1,5 × IQR) e escore-z modificado de Iglewicz e Hoaglin:
+// |0,6745 × (x − mediana) / MAD| acima de 3,5. Com MAD zero (mais da metade
+// dos valores iguais), o escore usa o desvio absoluto médio:
+// |(x − mediana) / (1,253314 × desvio médio)|.
+const (
+	iqrFenceFactor       = 1.5
+	madZScoreThreshold   = 3.5
+	madZScoreConstant    = 0.6745
+	meanADZScoreConstant = 1.253314
+	// Mínimo de valores numéricos para procurar outliers.
+	outlierMinValues = 10
+	// Extremos guardados de cada lado; também é o limite de exemplos.
+	outlierExtremesLimit = 10
+)
+
+// OutlierProfile resume os valores extremos de uma coluna numérica. Um
+// valor só é outlier quando fica fora das duas cercas (IQR e MAD), o que
+// evita alarmes em colunas assimétricas como frete e peso. Em colunas quase
+// constantes (IQR zero, como 0, 0, …, 999999), vale só a cerca do MAD.
+type OutlierProfile struct {
+	Count      int            `json:"count"`
+	Ratio      float64        `json:"ratio"`               // sobre os valores numéricos
+	Estimated  bool           `json:"estimated,omitempty"` // contagem extrapolada da amostra
+	LowerFence float64        `json:"lower_fence"`
+	UpperFence float64        `json:"upper_fence"`
+	IQRFences  []float64      `json:"iqr_fences"`
+	MADFences  []float64      `json:"mad_fences,omitempty"` // ausente em colunas sem dispersão
+	Examples   []OutlierValue `json:"examples,omitempty"`
+}
+
+// OutlierValue é um outlier com a linha do arquivo de origem (zero quando
+// desconhecida).
+type OutlierValue struct {
+	Line  int     `json:"line,omitempty"`
+	Value float64 `json:"value"`
+}
+
+// extremeTally guarda os menores e os maiores valores da coluna com suas
+// linhas, ordenados do mais extremo para o menos.
+type extremeTally struct {
+	lowest  []OutlierValue
+	highest []OutlierValue
+}
+
+func (et *extremeTally) add(value float64, line int) {
+	v := OutlierValue{Line: line, Value: value}
+	et.lowest = insertExtreme(et.lowest, v, func(a, b float64) bool { return a < b })
+	et.highest = insertExtreme(et.highest, v, func(a, b float64) bool { return a > b })
+}
+
+func (et *extremeTally) merge(other extremeTally) {
+	for _, v := range other.lowest {
+		et.lowest = insertExtreme(et.lowest, v, func(a, b float64) bool { return a < b })
+	}
+	for _, v := range other.highest {
+		et.highest = insertExtreme(et.highest, v, func(a, b float64) bool { return a > b })
+	}
+}
+
+func insertExtreme(list []OutlierValue, v OutlierValue, moreExtreme func(a, b float64) bool) []OutlierValue {
+	if len(list) == outlierExtremesLimit && !moreExtreme(v.Value, list[len(list)-1].Value) {
+		return list
+	}
+	i := len(list)
+	for i > 0 && moreExtreme(v.Value, list[i-1].Value) {
+		i--
+	}
+	list = slices.Insert(list, i, v)
+	if len(list) > outlierExtremesLimit {
+		list = list[:outlierExtremesLimit]
+	}
+	return list
+}
+
+// profile calcula as cercas com os quartis do resumo e o MAD de values (a
+// amostra de reservatório no streaming). Com exact, values traz a coluna
+// inteira e a contagem é exata; senão, quando todos os extremos guardados de
+// um lado são outliers, a contagem daquele lado é extrapolada da amostra.
+func (et *extremeTally) profile(summary *numericSummary, values []float64, exact bool) *OutlierProfile {
+	if summary.count < outlierMinValues || len(values) == 0 {
+		return nil
+	}
+	sketch := summary.quantileSketch
+	q1, median, q3 := sketch.quantile(0.25), sketch.quantile(0.5), sketch.quantile(0.75)
+	iqr := q3 - q1
+	width := madFenceWidth(values, median)
+	if iqr == 0 && width == 0 {
+		return nil
+	}
+
+	p := &OutlierProfile{
+		LowerFence: q1 - iqrFenceFactor*iqr,
+		UpperFence: q3 + iqrFenceFactor*iqr,
+	}
+	p.IQRFences = []float64{roundStat(p.LowerFence), roundStat(p.UpperFence)}
+
+	if width > 0 {
+		madLower, madUpper := median-width, median+width
+		p.MADFences = []float64{roundStat(madLower), roundStat(madUpper)}
+		p.LowerFence = min(p.LowerFence, madLower)
+		p.UpperFence = max(p.UpperFence, madUpper)
+	}
+
+	below := outsideFence(et.lowest, func(x float64) bool { return x < p.LowerFence })
+	above := outsideFence(et.highest, func(x float64) bool { return x > p.UpperFence })
+	p.Examples = append(slices.Clone(et.lowest[:below]), et.highest[:above]...)
+	slices.SortFunc(p.Examples, func(a, b OutlierValue) int { return cmp.Compare(a.Line, b.Line) })
+
+	if exact {
+		for _, x := range values {
+			if x < p.LowerFence || x > p.UpperFence {
+				p.Count++
+			}
+		}
+	} else {
+		sampledBelow, sampledAbove := 0, 0
+		for _, x := range values {
+			if x < p.LowerFence {
+				sampledBelow++
+			} else if x > p.UpperFence {
+				sampledAbove++
+			}
+		}
+		scale := float64(summary.count) / float64(len(values))
+		if below == outlierExtremesLimit {
+			below = max(below, int(math.Round(float64(sampledBelow)*scale)))
+			p.Estimated = true
+		}
+		if above == outlierExtremesLimit {
+			above = max(above, int(math.Round(float64(sampledAbove)*scale)))
+			p.Estimated = true
+		}
+		p.Count = below + above
+	}
+
+	p.Ratio = float64(p.Count) / float64(summary.count)
+	p.LowerFence, p.UpperFence = roundStat(p.LowerFence), roundStat(p.UpperFence)
+	return p
+}
+
+func outsideFence(extremes []OutlierValue, outside func(float64) bool) int {
+	n := 0
+	for n < len(extremes) && outside(extremes[n].Value) {
+		n++
+	}
+	return n
+}
+
+// madFenceWidth é a distância da mediana a partir da qual o escore-z
+// modificado passa do limite; zero quando values não tem dispersão.
+func madFenceWidth(values []float64, median float64) float64 {
+	if mad := medianAbsoluteDeviation(values, median); mad > 0 {
+		return madZScoreThreshold * mad / madZScoreConstant
+	}
+	sum := 0.0
+	for _, x := range values {
+		sum += math.Abs(x - median)
+	}
+	meanAD := sum / float64(len(values))
+	return madZScoreThreshold * meanADZScoreConstant * meanAD
+}
+
+func medianAbsoluteDeviation(values []float64, median float64) float64 {
+	deviations := make([]float64, len(values))
+	for i, x := range values {
+		deviations[i] = math.Abs(x - median)
+	}
+	slices.Sort(deviations)
+	mid := len(deviations) / 2
+	if len(deviations)%2 == 0 {
+		return (deviations[mid-1] + deviations[mid]) / 2
+	}
+	return deviations[mid]
+}
+
+func roundStat(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// applyOutlierSLA rebaixa para WARNING colunas saudáveis com mais outliers
+// que o limite configurado em WithOutlierSLA.
+func applyOutlierSLA(cfg *Config, score QualityScore, reason string, outliers *OutlierProfile) (QualityScore, string) {
+	if !cfg.outlierSLA || outliers == nil || score != SlaGood || outliers.Ratio <= cfg.maxOutlierRatio {
+		return score, reason
+	}
+	return SlaWarning, fmt.Sprintf("Atenção: %.1f%% de outliers (fora de %s a %s)",
+		outliers.Ratio*100, formatStat(outliers.LowerFence), formatStat(outliers.UpperFence))
+}
@@ -0,0 +1,110 @@
+package profiler
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestOutliers(t *testing.T) {
+	var values []string
+	for i := range 100 {
+		values = append(values, fmt.Sprintf("%d,5", 10+i%20))
+	}
+	values[40] = "999999"
+	values[70] = ""
+
+	for mode, result := range analyzeBoth("peso_kg", values) {
+		p := result.Outliers
+		if p == nil {
+			t.Fatalf("[%s] Outliers não deveria ser nil", mode)
+		}
+		if p.Count != 1 || p.Estimated {
+			t.Errorf("[%s] Count = %d (estimado: %v); esperava 1 exato", mode, p.Count, p.Estimated)
+		}
+		if len(p.Examples) != 1 || p.Examples[0] != (OutlierValue{Line: 42, Value: 999999}) {
+			t.Errorf("[%s] Examples = %+v; esperava o 999999 da linha 42", mode, p.Examples)
+		}
+		if p.UpperFence < 29.5 || p.UpperFence > 100 {
+			t.Errorf("[%s] UpperFence = %f fora do esperado", mode, p.UpperFence)
+		}
+		if len(p.IQRFences) != 2 || len(p.MADFences) != 2 {
+			t.Errorf("[%s] esperava as cercas IQR e MAD, recebeu %v e %v", mode, p.IQRFences, p.MADFences)
+		}
+		if result.SLA != SlaGood {
+			t.Errorf("[%s] sem WithOutlierSLA o SLA não muda, recebeu %s", mode, result.SLA)
+		}
+	}
+
+	acc := NewColumnAccumulator("peso_kg", WithOutlierSLA(0.005))
+	for i, v := range values {
+		acc.AddAt(v, i+2)
+	}
+	streaming := acc.Result()
+	sync := AnalyzeColumn(Column{Name: "peso_kg", Values: values}, WithOutlierSLA(0.005))
+	for mode, result := range map[string]ColumnResult{"streaming": streaming, "sync": sync} {
+		if result.SLA != SlaWarning {
+			t.Errorf("[%s] esperava WARNING com 1%% de outliers, recebeu %s (%s)", mode, result.SLA, result.SlaReason)
+		}
+	}
+}
+
+func TestOutliers_EstimatedCount(t *testing.T) {
+	var values []string
+	for i := range 1000 {
+		values = append(values, fmt.Sprint(i+1))
+	}
+	for range 50 {
+		values = append(values, "999999")
+	}
+
+	// Semente fixa para a amostra de reservatório: a contagem extrapolada
+	// fica determinística.
+	acc := NewColumnAccumulator("frete")
+	acc.rng = rand.New(rand.NewPCG(1, 2))
+	for i, v := range values {
+		acc.AddAt(v, i+2)
+	}
+	results := map[string]ColumnResult{
+		"streaming": acc.Result(),
+		"sync":      AnalyzeColumn(Column{Name: "frete", Values: values}),
+	}
+
+	for mode, result := range results {
+		p := result.Outliers
+		if p == nil {
+			t.Fatalf("[%s] Outliers não deveria ser nil", mode)
+		}
+		if math.Abs(float64(p.Count-50)) > 2 {
+			t.Errorf("[%s] Count = %d; esperava ~50", mode, p.Count)
+		}
+		if len(p.Examples) != outlierExtremesLimit {
+			t.Errorf("[%s] esperava %d exemplos, recebeu %d", mode, outlierExtremesLimit, len(p.Examples))
+		}
+		if mode == "streaming" && !p.Estimated {
+			t.Errorf("[%s] contagem acima dos extremos guardados deveria ser estimada", mode)
+		}
+	}
+}
+
+func TestOutliers_NoSpread(t *testing.T) {
+	values := []string{"0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0"}
+	for mode, result := range analyzeBoth("desconto", values) {
+		if result.Outliers != nil {
+			t.Errorf("[%s] coluna constante não deveria ter perfil, recebeu %+v", mode, result.Outliers)
+		}
+	}
+}
+
+func TestOutliers_NearlyConstant(t *testing.T) {
+	values := []string{"0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "999999"}
+	for mode, result := range analyzeBoth("desconto", values) {
+		if result.Outliers == nil {
+			t.Fatalf("[%s] IQR e MAD zero não deveriam esconder o 999999", mode)
+		}
+		if result.Outliers.Count != 1 || result.Outliers.Examples[0].Value != 999999 {
+			t.Errorf("[%s] Esperava só o 999999 como outlier, recebeu %+v", mode, result.Outliers)
+		}
+	}
+}
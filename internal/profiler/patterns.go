@@ -0,0 +1,90 @@
+package profiler
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Padrões mais longos que isso são truncados, para que textos livres não
+// gerem um padrão por valor.
+const maxPatternLength = 32
+
+// Formatos reportados por coluna quando WithTopPatterns não é usado.
+const defaultTopPatterns = 10
+
+// PatternCount é um formato ("shape") de valor com sua contagem aproximada e
+// um exemplo real.
+type PatternCount struct {
+	Pattern string `json:"pattern"`
+	Count   int    `json:"count"`
+	Error   int    `json:"error,omitempty"`
+	Example string `json:"example"`
+}
+
+// ValueShape resume o formato de um valor: letras maiúsculas viram "A",
+// minúsculas "a", dígitos "9" e os demais caracteres são mantidos
+// ("ABC-1234" → "AAA-9999", "12.345-678" → "99.999-999").
+func ValueShape(value string) string {
+	var b strings.Builder
+	b.Grow(min(len(value), maxPatternLength+1))
+
+	n := 0
+	for _, r := range value {
+		if n == maxPatternLength {
+			b.WriteString("…")
+			break
+		}
+		switch {
+		case unicode.IsDigit(r):
+			b.WriteByte('9')
+		case unicode.IsUpper(r):
+			b.WriteByte('A')
+		case unicode.IsLetter(r):
+			b.WriteByte('a')
+		default:
+			b.WriteRune(r)
+		}
+		n++
+	}
+	return b.String()
+}
+
+func topPatterns(s *spaceSaving, n int) []PatternCount {
+	items := s.ranked(n)
+	if items == nil {
+		return nil
+	}
+	patterns := make([]PatternCount, len(items))
+	for i, item := range items {
+		patterns[i] = PatternCount{Pattern: item.value, Count: item.count, Error: item.err, Example: item.example}
+	}
+	return patterns
+}
+
+// explainSLA complementa o motivo do SLA, quando ele se deve à consistência,
+// com o formato mais comum entre os valores fora do padrão (mismatch) e o
+// principal da coluna ("3.0% dos valores seguem 99999999 em vez de
+// 99999-999"). Variantes válidas, como CPF com e sem máscara, não são
+// apontadas; se os valores fora do padrão têm o formato principal, o motivo
+// fica como está.
+func explainSLA(score QualityScore, reason string, blankRatio, consistencyRatio float64, dtype DataType, patterns []PatternCount, mismatch PatternCount, filled int) string {
+	if score == SlaGood || mismatch.Count == 0 || filled == 0 {
+		return reason
+	}
+
+	severity := getSeverity(dtype)
+	completeness := evaluateCompleteness(blankRatio, severity)
+	consistency := evaluateConsistency(consistencyRatio, severity)
+	fromConsistency := (consistency == SlaCritical && completeness != SlaCritical) ||
+		(consistency == SlaWarning && completeness == SlaGood)
+	if !fromConsistency {
+		return reason
+	}
+
+	if len(patterns) == 0 || patterns[0].Pattern == mismatch.Pattern {
+		return reason
+	}
+	return fmt.Sprintf("%s. %.1f%% dos valores seguem %s em vez de %s",
+		reason, float64(mismatch.Count)/float64(filled)*100, mismatch.Pattern, patterns[0].Pattern)
+}
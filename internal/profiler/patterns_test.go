@@ -0,0 +1,89 @@
+package profiler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValueShape(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"ABC-1234", "AAA-9999"},
+		{"12.345-678", "99.999-999"},
+		{"São Paulo", "Aaa Aaaaa"},
+		{"contato@empresa.com", "aaaaaaa@aaaaaaa.aaa"},
+		{strings.Repeat("x", 40), strings.Repeat("a", 32) + "…"},
+	}
+
+	for _, tt := range tests {
+		if got := ValueShape(tt.value); got != tt.expected {
+			t.Errorf("ValueShape(%q) = %q; esperava %q", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestTopPatternsExplainSLA(t *testing.T) {
+	var values []string
+	for i := 0; i < 97; i++ {
+		values = append(values, "01310-100")
+	}
+	values = append(values, "0131-0100", "0131-0100", "0131-0101")
+
+	acc := NewColumnAccumulator("cep_destino")
+	for _, v := range values {
+		acc.Add(v)
+	}
+	streaming := acc.Result()
+	sync := AnalyzeColumn(Column{Name: "cep_destino", Values: values})
+
+	for name, result := range map[string]ColumnResult{"streaming": streaming, "sync": sync} {
+		if len(result.TopPatterns) != 2 {
+			t.Fatalf("[%s] Esperava 2 padrões, recebeu %v", name, result.TopPatterns)
+		}
+		main, other := result.TopPatterns[0], result.TopPatterns[1]
+		if main.Pattern != "99999-999" || main.Count != 97 || main.Example != "01310-100" {
+			t.Errorf("[%s] Padrão principal inesperado: %+v", name, main)
+		}
+		if other.Pattern != "9999-9999" || other.Count != 3 || other.Example != "0131-0100" {
+			t.Errorf("[%s] Padrão minoritário inesperado: %+v", name, other)
+		}
+
+		if result.SLA != SlaWarning {
+			t.Errorf("[%s] Esperava SLA WARNING, recebeu %s", name, result.SLA)
+		}
+		if !strings.Contains(result.SlaReason, "3.0% dos valores seguem 9999-9999 em vez de 99999-999") {
+			t.Errorf("[%s] Motivo do SLA sem o padrão minoritário: %q", name, result.SlaReason)
+		}
+	}
+}
+
+func TestExplainSLA_KeepsCompletenessReason(t *testing.T) {
+	patterns := []PatternCount{{Pattern: "99999-999", Count: 50}, {Pattern: "99999999", Count: 10}}
+
+	reason := explainSLA(SlaCritical, "Volume crítico de dados ausentes (40.0% vazios)", 0.4, 0.8, TypeCEP, patterns, patterns[1], 60)
+	if strings.Contains(reason, "seguem") {
+		t.Errorf("Motivo de vazios não deveria citar padrões: %q", reason)
+	}
+}
+
+func TestExplainSLA_IgnoresValidVariants(t *testing.T) {
+	var values []string
+	for i := 0; i < 60; i++ {
+		values = append(values, "529.982.247-25")
+	}
+	for i := 0; i < 38; i++ {
+		values = append(values, "52998224725")
+	}
+	values = append(values, "CPF-PENDENTE", "CPF-PENDENTE")
+
+	for name, result := range analyzeBoth("cpf_cliente", values) {
+		if result.MainType != TypeCPF {
+			t.Fatalf("[%s] Esperava CPF, recebeu %s", name, result.MainType)
+		}
+		if !strings.Contains(result.SlaReason, "2.0% dos valores seguem AAA-AAAAAAAA em vez de 999.999.999-99") {
+			t.Errorf("[%s] Motivo do SLA deveria citar o formato inválido: %q", name, result.SlaReason)
+		}
+	}
+}
@@ -5,13 +5,29 @@ import (
 	"log/slog"
 	"math/rand/v2"
 	"strings"
+	"sync"
 	"time"
 )
 
 type StreamData struct {
 	Row        []string
 	LineNumber int
-	Err        error
+	// Missing lista, em ordem crescente, as colunas ausentes do registro
+	// (campos que um JSONL não trouxe). Row tem "" nessas posições.
+	Missing []int
+	// Extra traz os valores adicionais de colunas que se repetem no registro
+	// (elementos de arrays no JSONL além do primeiro, que fica em Row).
+	Extra []Cell
+	// Kinds traz o tipo nativo JSON de cada posição de Row (nil no CSV).
+	Kinds []ValueKind
+	Err   error
+}
+
+// Cell é um valor avulso de uma coluna, identificada pelo índice.
+type Cell struct {
+	Column int
+	Value  string
+	Kind   ValueKind
 }
 type DirtyLine struct {
 	Line   int    `json:"line"`
@@ -28,7 +44,7 @@ type ProfilerResult struct {
 	DirtyLines      []DirtyLine    `json:"dirty_lines"`
 }
 
-func Profile(logger *slog.Logger, columns []Column, fileName string) (columnResult ProfilerResult) {
+func Profile(logger *slog.Logger, columns []Column, fileName string, opts ...Option) (columnResult ProfilerResult) {
 	if logger == nil {
 		logger = slog.New(slog.NewJSONHandler(io.Discard, nil))
 	}
@@ -45,7 +61,7 @@ func Profile(logger *slog.Logger, columns []Column, fileName string) (columnResu
 	)
 
 	for i, col := range columns {
-		columnResult.Columns = append(columnResult.Columns, AnalyzeColumn(col))
+		columnResult.Columns = append(columnResult.Columns, AnalyzeColumn(col, opts...))
 		logger.Debug("Coluna analisada",
 			"index", i+1,
 			"column_name", col.Name,
@@ -60,15 +76,68 @@ func Profile(logger *slog.Logger, columns []Column, fileName string) (columnResu
 	return
 }
 
-func ProfileAsync(logger *slog.Logger, headers []string, dataChan <-chan StreamData, fileName string) (profilerResult ProfilerResult) {
+// Linhas enviadas de uma vez a cada worker do ProfileAsync.
+const rowBatchSize = 256
+
+type rowBatch struct {
+	rows    [][]string
+	lines   []int
+	missing [][]int
+	extra   [][]Cell
+	kinds   [][]ValueKind
+}
+
+// ProfileAsync consome o stream distribuindo lotes de linhas, em rodízio,
+// entre os workers (WithWorkers). Cada worker tem seus próprios acumuladores,
+// mesclados na ordem dos workers ao final: o resultado só varia entre
+// execuções nas amostras aleatórias.
+func ProfileAsync(logger *slog.Logger, headers []string, dataChan <-chan StreamData, fileName string, opts ...Option) (profilerResult ProfilerResult) {
 	if logger == nil {
 		logger = slog.New(slog.NewJSONHandler(io.Discard, nil))
 	}
 	setResultMetadata(headers, &profilerResult, fileName)
-
-	accumulators := make([]*ColumnAccumulator, profilerResult.TotalColumns)
-	for i, name := range headers {
-		accumulators[i] = NewColumnAccumulator(name)
+	cfg := newConfig(opts)
+
+	shards := make([][]*ColumnAccumulator, cfg.workers)
+	batches := make([]chan rowBatch, cfg.workers)
+	var wg sync.WaitGroup
+	for w := range shards {
+		shards[w] = make([]*ColumnAccumulator, profilerResult.TotalColumns)
+		for i, name := range headers {
+			shards[w][i] = NewColumnAccumulator(name, opts...)
+		}
+		batches[w] = make(chan rowBatch, 4)
+
+		wg.Add(1)
+		go func(accumulators []*ColumnAccumulator, batches <-chan rowBatch) {
+			defer wg.Done()
+			for batch := range batches {
+				for j, record := range batch.rows {
+					missing, kinds := batch.missing[j], batch.kinds[j]
+					for i, value := range record {
+						if i >= len(accumulators) {
+							break
+						}
+						if len(missing) > 0 && missing[0] == i {
+							accumulators[i].AddMissing()
+							missing = missing[1:]
+							continue
+						}
+						kind := KindUnknown
+						if i < len(kinds) {
+							kind = kinds[i]
+						}
+						accumulators[i].AddTyped(value, kind, batch.lines[j])
+					}
+					for _, cell := range batch.extra[j] {
+						if cell.Column < len(accumulators) {
+							accumulators[cell.Column].AddRepeated(cell.Value, cell.Kind, batch.lines[j])
+						}
+					}
+					PutRowSlice(record)
+				}
+			}
+		}(shards[w], batches[w])
 	}
 
 	const previewSize = 50
@@ -78,6 +147,17 @@ func ProfileAsync(logger *slog.Logger, headers []string, dataChan <-chan StreamD
 
 	rowCount := 0
 	dirtyLines := []DirtyLine{}
+	batch := rowBatch{}
+	nextWorker := 0
+	dispatch := func() {
+		if len(batch.rows) == 0 {
+			return
+		}
+		batches[nextWorker] <- batch
+		nextWorker = (nextWorker + 1) % len(batches)
+		batch = rowBatch{}
+	}
+
 	for msg := range dataChan {
 
 		if msg.Err != nil {
@@ -92,12 +172,6 @@ func ProfileAsync(logger *slog.Logger, headers []string, dataChan <-chan StreamD
 		record := msg.Row
 		rowCount++
 
-		for i, value := range record {
-			if i < len(accumulators) {
-				accumulators[i].Add(value)
-			}
-		}
-
 		if len(sampleRows) < previewSize {
 			rowCopy := make([]string, len(record))
 			copy(rowCopy, record)
@@ -111,12 +185,38 @@ func ProfileAsync(logger *slog.Logger, headers []string, dataChan <-chan StreamD
 			}
 		}
 
-		PutRowSlice(record)
+		if batch.rows == nil {
+			batch.rows = make([][]string, 0, rowBatchSize)
+			batch.lines = make([]int, 0, rowBatchSize)
+			batch.missing = make([][]int, 0, rowBatchSize)
+			batch.extra = make([][]Cell, 0, rowBatchSize)
+			batch.kinds = make([][]ValueKind, 0, rowBatchSize)
+		}
+		batch.rows = append(batch.rows, record)
+		batch.lines = append(batch.lines, msg.LineNumber)
+		batch.missing = append(batch.missing, msg.Missing)
+		batch.extra = append(batch.extra, msg.Extra)
+		batch.kinds = append(batch.kinds, msg.Kinds)
+		if len(batch.rows) == rowBatchSize {
+			dispatch()
+		}
 
 		if rowCount%200000 == 0 {
 			logger.Info("Processamento em andamento", "rows_processed", rowCount)
 		}
 	}
+	dispatch()
+	for _, ch := range batches {
+		close(ch)
+	}
+	wg.Wait()
+
+	accumulators := shards[0]
+	for _, shard := range shards[1:] {
+		for i, acc := range shard {
+			accumulators[i].Merge(acc)
+		}
+	}
 
 	columnResults := make([]ColumnResult, len(headers))
 	for i, acc := range accumulators {
@@ -129,6 +229,7 @@ func ProfileAsync(logger *slog.Logger, headers []string, dataChan <-chan StreamD
 		"total_columns", len(headers),
 		"filename", fileName,
 		"dirty_lines", len(dirtyLines),
+		"workers", cfg.workers,
 	)
 	profilerResult.DirtyLines = dirtyLines
 	profilerResult.DirtyLinesCount = len(dirtyLines)
@@ -85,6 +85,31 @@ func TestProfileAsync(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Campos Ausentes (JSONL)", func(t *testing.T) {
+		headers := []string{"id", "opcional"}
+		dataChan := make(chan StreamData)
+
+		go func() {
+			defer close(dataChan)
+			dataChan <- StreamData{Row: []string{"1", "x"}, LineNumber: 1}
+			dataChan <- StreamData{Row: []string{"2", ""}, LineNumber: 2, Missing: []int{1}}
+			dataChan <- StreamData{Row: []string{"3", ""}, LineNumber: 3}
+			dataChan <- StreamData{Row: []string{"4", ""}, LineNumber: 4, Missing: []int{1}}
+		}()
+
+		result := ProfileAsync(logger, headers, dataChan, "eventos.jsonl", WithWorkers(2))
+		id, opcional := result.Columns[0], result.Columns[1]
+		if id.PresenceRatio != 1 || id.MissingCount != 0 {
+			t.Errorf("id está em todos os registros: presence=%v missing=%d", id.PresenceRatio, id.MissingCount)
+		}
+		if opcional.PresenceRatio != 0.5 || opcional.MissingCount != 2 {
+			t.Errorf("opcional falta em 2 de 4: presence=%v missing=%d", opcional.PresenceRatio, opcional.MissingCount)
+		}
+		// Ausente e vazio contam como vazio.
+		if opcional.BlankCount != 3 {
+			t.Errorf("Esperava 3 vazios, recebeu %d", opcional.BlankCount)
+		}
+	})
 }
 
 func TestProfileAsync_Integration(t *testing.T) {
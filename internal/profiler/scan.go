@@ -0,0 +1,247 @@
+package profiler
+
+import (
+	"regexp"
+	"strings"
+)
+
+// scanners reimplementa cada expressão regular nativa como uma varredura
+// única do valor, sem alocação: o tamanho descarta a maioria dos candidatos
+// e o restante é conferido byte a byte por classe de caractere. As Regex*
+// continuam exportadas como especificação de cada formato.
+var scanners = map[*regexp.Regexp]func(string) bool{
+	RegexFiscalKey:   matchFiscalKey,
+	RegexPlaca:       matchPlaca,
+	RegexVIN:         matchVIN,
+	RegexCPF:         matchCPF,
+	RegexCNPJ:        matchCNPJ,
+	RegexCNPJAlnum:   matchCNPJAlnum,
+	RegexPIS:         matchPIS,
+	RegexRENAVAM:     matchRENAVAM,
+	RegexTitulo:      matchTitulo,
+	RegexCNS:         matchCNS,
+	RegexNCM:         matchNCM,
+	RegexCFOP:        matchCFOP,
+	RegexCST:         matchCST,
+	RegexCSOSN:       matchCSOSN,
+	RegexCEST:        matchCEST,
+	RegexDateBr:      matchDateBr,
+	RegexDateIso:     matchDateIso,
+	RegexDatetimeIso: matchDatetimeIso,
+	RegexDatetimeBr:  matchDatetimeBr,
+	RegexTime:        matchTime,
+	RegexEpoch:       matchEpoch,
+	RegexContainer:   matchContainer,
+	Regex8Digits:     match8Digits,
+	Regex11Digits:    match11Digits,
+	RegexCEP:         matchCEP,
+	RegexMobile:      matchMobile,
+	RegexEmail:       matchEmail,
+	RegexEAN:         matchEAN,
+}
+
+func matchFiscalKey(v string) bool { return digitsLen(v, 44, 44) }
+func matchCPF(v string) bool       { return matchMask(v, "###.###.###-##") }
+func matchCNPJ(v string) bool      { return matchMask(v, "**.***.***/****-##") }
+func matchCNPJAlnum(v string) bool { return matchMask(v, "************##") }
+func matchPIS(v string) bool       { return matchMask(v, "###.#####.##-#") }
+func matchRENAVAM(v string) bool   { return digitsLen(v, 9, 9) || digitsLen(v, 11, 11) }
+func matchTitulo(v string) bool    { return digitGroups(v, ' ', 4, 4, 4) }
+func matchCNS(v string) bool       { return digitGroups(v, ' ', 3, 4, 4, 4) }
+func matchNCM(v string) bool       { return matchMask(v, "####.##.##") }
+func matchCFOP(v string) bool      { return digitGroups(v, '.', 1, 3) }
+func matchCST(v string) bool       { return digitsLen(v, 2, 3) }
+func matchCSOSN(v string) bool     { return digitsLen(v, 3, 4) }
+func matchCEST(v string) bool      { return digitGroups(v, '.', 2, 3, 2) }
+func matchDateBr(v string) bool    { return matchMask(v, "##/##/####") }
+func matchDateIso(v string) bool   { return matchMask(v, "####-##-##") }
+func matchEpoch(v string) bool     { return digitsLen(v, 10, 10) || digitsLen(v, 13, 13) }
+func matchContainer(v string) bool { return matchMask(v, "@@@@#######") }
+func match8Digits(v string) bool   { return digitsLen(v, 8, 8) }
+func match11Digits(v string) bool  { return digitsLen(v, 11, 11) }
+func matchCEP(v string) bool       { return matchMask(v, "#####-###") }
+func matchEAN(v string) bool       { return digitsLen(v, 13, 14) }
+
+func matchPlaca(v string) bool {
+	return matchMask(v, "@@@#*##") || matchMask(v, "@@@-#*##")
+}
+
+func matchDatetimeBr(v string) bool {
+	return matchMask(v, "##/##/#### ##:##") || matchMask(v, "##/##/#### ##:##:##")
+}
+
+// matchVIN aceita 17 dígitos ou letras maiúsculas, exceto I, O e Q.
+func matchVIN(v string) bool {
+	if len(v) != 17 {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		if !isDigitByte(c) && (!isUpperByte(c) || c == 'I' || c == 'O' || c == 'Q') {
+			return false
+		}
+	}
+	return true
+}
+
+func matchDatetimeIso(v string) bool {
+	if len(v) < 16 || !matchMask(v[:10], "####-##-##") || (v[10] != 'T' && v[10] != ' ') || !matchMask(v[11:16], "##:##") {
+		return false
+	}
+	rest := v[16:]
+	if strings.HasPrefix(rest, ":") {
+		var ok bool
+		if rest, ok = trimSeconds(rest); !ok {
+			return false
+		}
+	}
+	if rest == "" || rest == "Z" {
+		return true
+	}
+	return (rest[0] == '+' || rest[0] == '-') && (matchMask(rest[1:], "##:##") || matchMask(rest[1:], "####"))
+}
+
+func matchTime(v string) bool {
+	if len(v) < 5 || !matchMask(v[:5], "##:##") {
+		return false
+	}
+	rest, ok := trimSeconds(v[5:])
+	return ok && rest == ""
+}
+
+// trimSeconds consome ":SS" e a fração opcional (até 9 dígitos) do início
+// de s. Sem ":" no início, devolve s inalterado.
+func trimSeconds(s string) (string, bool) {
+	if s == "" || s[0] != ':' {
+		return s, true
+	}
+	if len(s) < 3 || !isDigitByte(s[1]) || !isDigitByte(s[2]) {
+		return s, false
+	}
+	s = s[3:]
+	if s == "" || s[0] != '.' {
+		return s, true
+	}
+	n := 1
+	for n < len(s) && isDigitByte(s[n]) {
+		n++
+	}
+	if n == 1 || n > 10 {
+		return s, false
+	}
+	return s[n:], true
+}
+
+// matchMobile aceita DDD com ou sem parênteses, um espaço opcional e o
+// número com 9 na frente, com ou sem hífen.
+func matchMobile(v string) bool {
+	i := 0
+	if i < len(v) && v[i] == '(' {
+		i++
+	}
+	if len(v) < i+2 || !isDigitByte(v[i]) || !isDigitByte(v[i+1]) {
+		return false
+	}
+	i += 2
+	if i < len(v) && v[i] == ')' {
+		i++
+	}
+	if i < len(v) && isSpaceByte(v[i]) {
+		i++
+	}
+	return matchMask(v[i:], "9########") || matchMask(v[i:], "9####-####")
+}
+
+// matchEmail exige parte local com letras, dígitos, "_", "-" ou ".", e
+// domínio com ao menos um ponto, sem rótulos vazios e com sufixo final de 2
+// a 4 caracteres.
+func matchEmail(v string) bool {
+	at := strings.IndexByte(v, '@')
+	if at < 1 {
+		return false
+	}
+	for i := 0; i < at; i++ {
+		if c := v[i]; !isWordByte(c) && c != '-' && c != '.' {
+			return false
+		}
+	}
+
+	domain := v[at+1:]
+	dot := strings.LastIndexByte(domain, '.')
+	if suffix := len(domain) - dot - 1; dot < 1 || suffix < 2 || suffix > 4 {
+		return false
+	}
+	prev := byte('.')
+	for i := 0; i < len(domain); i++ {
+		c := domain[i]
+		if c == '.' && prev == '.' || c != '.' && !isWordByte(c) && c != '-' {
+			return false
+		}
+		prev = c
+	}
+	return true
+}
+
+// matchMask compara value com a máscara posição a posição: '#' aceita um
+// dígito, '@' uma letra maiúscula e '*' qualquer um dos dois; os demais
+// bytes da máscara são literais.
+func matchMask(value, mask string) bool {
+	if len(value) != len(mask) {
+		return false
+	}
+	for i := 0; i < len(mask); i++ {
+		c := value[i]
+		switch mask[i] {
+		case '#':
+			if !isDigitByte(c) {
+				return false
+			}
+		case '@':
+			if !isUpperByte(c) {
+				return false
+			}
+		case '*':
+			if !isDigitByte(c) && !isUpperByte(c) {
+				return false
+			}
+		default:
+			if c != mask[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// digitGroups aceita grupos de dígitos com os tamanhos informados, cada um
+// separado do anterior por sep opcional.
+func digitGroups(value string, sep byte, sizes ...int) bool {
+	i := 0
+	for g, size := range sizes {
+		if g > 0 && i < len(value) && value[i] == sep {
+			i++
+		}
+		if len(value) < i+size || !isDigits(value[i:i+size]) {
+			return false
+		}
+		i += size
+	}
+	return i == len(value)
+}
+
+func digitsLen(value string, minLen, maxLen int) bool {
+	return len(value) >= minLen && len(value) <= maxLen && isDigits(value)
+}
+
+func isDigitByte(c byte) bool { return c >= '0' && c <= '9' }
+func isUpperByte(c byte) bool { return c >= 'A' && c <= 'Z' }
+
+// isWordByte equivale ao \w das expressões regulares (ASCII).
+func isWordByte(c byte) bool {
+	return isDigitByte(c) || isUpperByte(c) || c >= 'a' && c <= 'z' || c == '_'
+}
+
+// isSpaceByte equivale ao \s das expressões regulares do Go.
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
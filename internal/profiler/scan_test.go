@@ -0,0 +1,113 @@
+package profiler
+
+import (
+	"strings"
+	"testing"
+)
+
+// scanCorpus reúne valores na fronteira de cada formato nativo.
+var scanCorpus = []string{
+	"", " ", "0", "00", "000", "0000", "12345678", "123456789", "12345678901", "1234567890123",
+	"12345678901234", "123456789012345", "35230912345678000190550010000000011000000000",
+	"ABC1234", "ABC-1234", "ABC1D23", "ABC-1D23", "abc1234", "AB1234", "ABC12345",
+	"1M8GDM9AXKP042788", "1M8GDM9AXKP04278I", "1M8GDM9AXKP04278Q", "1m8gdm9axkp042788",
+	"123.456.789-09", "123.456.789-0", "123456789-09", "123.456.789/09",
+	"12.345.678/0001-90", "12.ABC.345/01DE-35", "12.abc.345/01de-35", "12ABC34501DE35", "12ABC34501DEAB",
+	"120.30203.09-4", "120.3020.309-4",
+	"1023 0000 0132", "1023 00000132", "10230000 0132", "1023  0000 0132", "1023 0000 013",
+	"170 1234 5678 0008", "1701234 5678 0008", "170 1234 56780008", "170 1234 5678 000",
+	"2203.00.00", "2203.00.0", "22030000",
+	"5.102", "5102", "51.02", "5.1020",
+	"01.001.00", "0100100", "01.00100", "01001.00", "01..001.00",
+	"25/12/2023", "2023-12-25", "2023/12/25", "25-12-2023", "2023-1-25",
+	"2024-05-01T13:45", "2024-05-01T13:45:00", "2024-05-01 13:45:00", "2024-05-01T13:45:00Z",
+	"2024-05-01T13:45:00.123-03:00", "2024-05-01T13:45:00.123456789Z", "2024-05-01T13:45:00.1234567890Z",
+	"2024-05-01T13:45:00.Z", "2024-05-01T13:45Z", "2024-05-01T13:45+0300", "2024-05-01T13:45:00+03",
+	"2024-05-01T13:45:0", "2024-05-01X13:45", "2024-05-01T13:45:00z",
+	"01/05/2024 13:45", "01/05/2024 13:45:00", "01/05/2024 13:45:0", "01/05/2024T13:45",
+	"13:45", "13:45:00", "13:45:00.5", "13:45:00.", "13:45:00.1234567890", "13:4", "13:45:",
+	"1714571100", "1714571100000", "17145711000",
+	"MSKU1234567", "MSKU123456", "MsKU1234567",
+	"01310-100", "01310100", "0131-0100",
+	"11 91234-5678", "(11) 91234-5678", "(11)912345678", "11912345678", "11\t91234-5678",
+	"11\v91234-5678", "(11 91234-5678", "11) 91234-5678", "11  91234-5678", "11 81234-5678",
+	"contato@empresa.com.br", "a@b.co", "a@b.c", "a@b.comms", "a.b-c_d@x-y.org", "@b.com",
+	"a@@b.com", "a@.com", "a@b..com", "a@com", "a b@c.com", "á@b.com", "a@b.c_m", "a@b-.--",
+	"true", "FALSE", "S", "n", "sim",
+}
+
+func TestScanners_MatchRegex(t *testing.T) {
+	for pattern, scan := range scanners {
+		for _, value := range scanCorpus {
+			if got, want := scan(value), pattern.MatchString(value); got != want {
+				t.Errorf("%s: scanner(%q) = %v; regex = %v", pattern, value, got, want)
+			}
+		}
+	}
+}
+
+func FuzzScanners(f *testing.F) {
+	for _, value := range scanCorpus {
+		f.Add(value)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		for pattern, scan := range scanners {
+			if got, want := scan(value), pattern.MatchString(value); got != want {
+				t.Errorf("%s: scanner(%q) = %v; regex = %v", pattern, value, got, want)
+			}
+		}
+	})
+}
+
+// regexDetectors devolve os detectores nativos avaliando as Regex*, como
+// referência para os scanners.
+func regexDetectors() []TypeDetector {
+	detectors := builtinDetectors()
+	for _, d := range detectors {
+		switch d := d.(type) {
+		case *patternDetector:
+			d.scan = nil
+		case *checksumDetector:
+			d.scan = nil
+		}
+	}
+	return detectors
+}
+
+func TestInferType_MatchesRegexDetectors(t *testing.T) {
+	reference := NewRegistry(regexDetectors()...)
+	headers := []string{"coluna_x", "cpf", "cnpj_fornecedor", "ean", "ncm", "cep", "renavam", "titulo", "cns", "timestamp", "cfop", "cst", "csosn", "cest"}
+	for _, header := range headers {
+		for _, value := range scanCorpus {
+			if got, want := InferType(value, header), reference.Infer(value, header); got != want {
+				t.Errorf("InferType(%q, %q) = %s; regex = %s", value, header, got, want)
+			}
+		}
+	}
+}
+
+func TestRegistry_ForColumn(t *testing.T) {
+	inferrer := DefaultRegistry().ForColumn("CPF_Cliente")
+	if got := inferrer.Infer("12345678901"); got != TypeCPF {
+		t.Errorf("Dica do cabeçalho ignorada: recebeu %s", got)
+	}
+	if got := inferrer.Infer(""); got != TypeEmpty {
+		t.Errorf("Esperava EMPTY, recebeu %s", got)
+	}
+	if got := DefaultRegistry().ForColumn("id_transacao").Infer("12345678901"); got != TypeInteger {
+		t.Errorf("Sem dica esperava INTEGER, recebeu %s", got)
+	}
+}
+
+func TestColumnInferrer_NoAllocs(t *testing.T) {
+	inferrer := DefaultRegistry().ForColumn("valor")
+	values := []string{
+		"123.456.789-09", "contato@empresa.com.br", "2024-05-01T13:45:00Z", "20231225",
+		"1.234,56", "R$ 10,00", "12,5%", "1.234", "TRUE", strings.Repeat("texto ", 3),
+	}
+	for _, value := range values {
+		if allocs := testing.AllocsPerRun(100, func() { inferrer.Infer(value) }); allocs != 0 {
+			t.Errorf("Infer(%q) alocou %.0f vezes", value, allocs)
+		}
+	}
+}
@@ -35,7 +35,7 @@ func CalculateSLA(blankRatio float64, consistencyRatio float64, dtype DataType)
 	scoreConsistency := evaluateConsistency(consistencyRatio, severity)
 
 	if scoreConsistency == SlaCritical {
-		return SlaCritical, fmt.Sprintf("Alta poluição de dados: %.1f%% dos valores não são %s", (1-consistencyRatio)*100, dtype)
+		return SlaCritical, fmt.Sprintf("Alta poluição de dados: %.1f%% dos valores não são %s válidos", (1-consistencyRatio)*100, dtype)
 	}
 
 	if scoreCompleteness == SlaWarning {
@@ -95,12 +95,5 @@ func evaluateConsistency(ratio float64, severity SeverityLevel) QualityScore {
 }
 
 func getSeverity(t DataType) SeverityLevel {
-	switch t {
-	case TypeCPF, TypeCNPJ, TypeFiscalKey44, TypePlaca, TypeRNTRC, TypeContainer:
-		return SeverityHigh
-	case TypeInteger, TypeFloat, TypeDate, TypeDateCompact, TypeEmail, TypeEAN, TypeMobile, TypeCEP:
-		return SeverityMedium
-	default:
-		return SeverityLow
-	}
+	return defaultRegistry.Severity(t)
 }
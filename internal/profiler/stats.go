@@ -1,40 +1,182 @@
 package profiler
 
-import "strconv"
+import (
+	"math"
+	"strconv"
+)
 
 type StatKey string
 
 const (
-	StatMin     StatKey = "min"
-	StatMax     StatKey = "max"
-	StatSum     StatKey = "sum"
-	StatAverage StatKey = "average"
+	StatMin      StatKey = "min"
+	StatMax      StatKey = "max"
+	StatSum      StatKey = "sum"
+	StatAverage  StatKey = "average"
+	StatVariance StatKey = "variance" // amostral (n-1), como no pandas
+	StatStdDev   StatKey = "std_dev"
+	StatSkewness StatKey = "skewness" // Fisher-Pearson ajustado
+	StatKurtosis StatKey = "kurtosis" // excesso, ajustado (0 para a normal)
+	StatP1       StatKey = "p1"
+	StatP5       StatKey = "p5"
+	StatP25      StatKey = "p25"
+	StatP50      StatKey = "p50" // mediana
+	StatP75      StatKey = "p75"
+	StatP95      StatKey = "p95"
+	StatP99      StatKey = "p99"
 )
 
+var quantileKeys = []struct {
+	key StatKey
+	q   float64
+}{
+	{StatP1, 0.01}, {StatP5, 0.05}, {StatP25, 0.25}, {StatP50, 0.50},
+	{StatP75, 0.75}, {StatP95, 0.95}, {StatP99, 0.99},
+}
+
 func StatsCalc(v []float64) map[StatKey]string {
 	if len(v) == 0 {
 		return nil
 	}
 
-	min, max := v[0], v[0]
-	sum := 0.0
-
+	summary := newNumericSummary()
 	for _, valor := range v {
-		if valor < min {
-			min = valor
-		}
-		if valor > max {
-			max = valor
-		}
-		sum += valor
+		summary.add(valor)
 	}
+	return summary.stats()
+}
 
-	avg := sum / float64(len(v))
+// numericSummary acumula as estatísticas numéricas em uma passada e memória
+// constante: momentos centrais pelo método de Welford (estendido até o
+// quarto momento) e quantis por t-digest. Dois resumos podem ser mesclados.
+type numericSummary struct {
+	count          int
+	sum            float64
+	min, max       float64
+	mean           float64
+	m2, m3, m4     float64
+	quantileSketch *tdigest
+}
 
-	return map[StatKey]string{
-		StatMin:     strconv.FormatFloat(min, 'f', 2, 64),
-		StatMax:     strconv.FormatFloat(max, 'f', 2, 64),
-		StatSum:     strconv.FormatFloat(sum, 'f', 2, 64),
-		StatAverage: strconv.FormatFloat(avg, 'f', 2, 64),
+func newNumericSummary() *numericSummary {
+	return &numericSummary{quantileSketch: newTDigest(tdigestCompression)}
+}
+
+func (s *numericSummary) add(x float64) {
+	if s.count == 0 || x < s.min {
+		s.min = x
+	}
+	if s.count == 0 || x > s.max {
+		s.max = x
 	}
+	s.sum += x
+
+	n1 := float64(s.count)
+	s.count++
+	n := float64(s.count)
+	delta := x - s.mean
+	deltaN := delta / n
+	deltaN2 := deltaN * deltaN
+	term1 := delta * deltaN * n1
+
+	s.mean += deltaN
+	s.m4 += term1*deltaN2*(n*n-3*n+3) + 6*deltaN2*s.m2 - 4*deltaN*s.m3
+	s.m3 += term1*deltaN*(n-2) - 3*deltaN*s.m2
+	s.m2 += term1
+
+	s.quantileSketch.add(x)
+}
+
+// merge combina outro resumo (fórmulas de Chan/Pébay para os momentos).
+func (s *numericSummary) merge(other *numericSummary) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	if s.count == 0 {
+		sketch := s.quantileSketch
+		*s = *other
+		s.quantileSketch = sketch
+		s.quantileSketch.merge(other.quantileSketch)
+		return
+	}
+
+	na, nb := float64(s.count), float64(other.count)
+	n := na + nb
+	delta := other.mean - s.mean
+	delta2 := delta * delta
+
+	m2 := s.m2 + other.m2 + delta2*na*nb/n
+	m3 := s.m3 + other.m3 +
+		delta*delta2*na*nb*(na-nb)/(n*n) +
+		3*delta*(na*other.m2-nb*s.m2)/n
+	m4 := s.m4 + other.m4 +
+		delta2*delta2*na*nb*(na*na-na*nb+nb*nb)/(n*n*n) +
+		6*delta2*(na*na*other.m2+nb*nb*s.m2)/(n*n) +
+		4*delta*(na*other.m3-nb*s.m3)/n
+
+	s.mean += delta * nb / n
+	s.m2, s.m3, s.m4 = m2, m3, m4
+	s.count += other.count
+	s.sum += other.sum
+	s.min = min(s.min, other.min)
+	s.max = max(s.max, other.max)
+	s.quantileSketch.merge(other.quantileSketch)
+}
+
+func (s *numericSummary) stats() map[StatKey]string {
+	if s.count == 0 {
+		return nil
+	}
+
+	stats := map[StatKey]string{
+		StatMin:     formatStat(s.min),
+		StatMax:     formatStat(s.max),
+		StatSum:     formatStat(s.sum),
+		StatAverage: formatStat(s.sum / float64(s.count)),
+	}
+
+	n := float64(s.count)
+	if s.count > 1 {
+		variance := s.m2 / (n - 1)
+		stats[StatVariance] = formatStat(variance)
+		stats[StatStdDev] = formatStat(math.Sqrt(variance))
+	}
+	if s.count > 2 {
+		stats[StatSkewness] = formatShape(s.skewness())
+	}
+	if s.count > 3 {
+		stats[StatKurtosis] = formatShape(s.kurtosis())
+	}
+
+	for _, qk := range quantileKeys {
+		stats[qk.key] = formatStat(s.quantileSketch.quantile(qk.q))
+	}
+	return stats
+}
+
+// skewness e kurtosis usam as correções de viés do pandas; colunas
+// constantes resultam em zero.
+func (s *numericSummary) skewness() float64 {
+	if s.m2 == 0 {
+		return 0
+	}
+	n := float64(s.count)
+	g1 := math.Sqrt(n) * s.m3 / math.Pow(s.m2, 1.5)
+	return g1 * math.Sqrt(n*(n-1)) / (n - 2)
+}
+
+func (s *numericSummary) kurtosis() float64 {
+	if s.m2 == 0 {
+		return 0
+	}
+	n := float64(s.count)
+	g2 := n*s.m4/(s.m2*s.m2) - 3
+	return ((n+1)*g2 + 6) * (n - 1) / ((n - 2) * (n - 3))
+}
+
+func formatStat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func formatShape(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
 }
@@ -1,6 +1,9 @@
 package profiler
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestStatsCalc(t *testing.T) {
 	t.Run("Deve calcular estatísticas de números positivos", func(t *testing.T) {
@@ -39,3 +42,91 @@ func checkStats(t *testing.T, got, expected map[StatKey]string) {
 		}
 	}
 }
+
+func TestStatsCalc_DispersionAndQuantiles(t *testing.T) {
+	// Valores de referência conferidos com pandas (var, std, skew, kurt, quantile).
+	got := StatsCalc([]float64{1, 2, 3, 4, 10, 7, 7, 2.5})
+
+	expected := map[StatKey]string{
+		StatVariance: "9.67",
+		StatStdDev:   "3.11",
+		StatSkewness: "0.7275",
+		StatKurtosis: "-0.6023",
+		StatP1:       "1.07",
+		StatP5:       "1.35",
+		StatP25:      "2.38",
+		StatP50:      "3.50",
+		StatP75:      "7.00",
+		StatP95:      "8.95",
+		StatP99:      "9.79",
+	}
+
+	checkStats(t, got, expected)
+}
+
+func TestStatsCalc_SmallSamples(t *testing.T) {
+	got := StatsCalc([]float64{42})
+
+	if got[StatP50] != "42.00" {
+		t.Errorf("Mediana de um único valor: recebeu %s", got[StatP50])
+	}
+	for _, key := range []StatKey{StatVariance, StatSkewness, StatKurtosis} {
+		if _, ok := got[key]; ok {
+			t.Errorf("%s não deveria existir com um único valor", key)
+		}
+	}
+}
+
+func TestNumericSummary_BoundedQuantileError(t *testing.T) {
+	const n = 100_000
+	summary := newNumericSummary()
+	// Permutação determinística de 0..n-1 (7919 é primo com n).
+	for i := 0; i < n; i++ {
+		summary.add(float64(i * 7919 % n))
+	}
+
+	for _, qk := range quantileKeys {
+		got := summary.quantileSketch.quantile(qk.q)
+		want := qk.q * (n - 1)
+		if math.Abs(got-want) > 0.01*n {
+			t.Errorf("%s: recebeu %.0f, esperava %.0f (erro acima de 1%%)", qk.key, got, want)
+		}
+	}
+	if len(summary.quantileSketch.centroids) > 5*tdigestCompression {
+		t.Errorf("Sketch não foi comprimido: %d centróides", len(summary.quantileSketch.centroids))
+	}
+}
+
+func TestNumericSummary_Merge(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 10, 7, 7, 2.5, -3, 18, 0.5}
+
+	whole := newNumericSummary()
+	left, right := newNumericSummary(), newNumericSummary()
+	for i, v := range values {
+		whole.add(v)
+		if i < 4 {
+			left.add(v)
+		} else {
+			right.add(v)
+		}
+	}
+	left.merge(right)
+
+	checkStats(t, left.stats(), whole.stats())
+}
+
+func TestStats_StreamingMatchesSync(t *testing.T) {
+	values := []string{"10", "20", "30", "40", "1.000,50", "7", "12,5"}
+
+	acc := NewColumnAccumulator("valor")
+	for _, v := range values {
+		acc.Add(v)
+	}
+	streaming := acc.Result().Stats
+	sync := AnalyzeColumn(Column{Name: "valor", Values: values}).Stats
+
+	if streaming[StatStdDev] == "" || streaming[StatP50] == "" {
+		t.Fatalf("Estatísticas de dispersão ausentes: %v", streaming)
+	}
+	checkStats(t, streaming, sync)
+}
@@ -0,0 +1,143 @@
+package profiler
+
+import (
+	"math"
+	"slices"
+)
+
+// Compressão padrão do t-digest: no máximo ~160 centróides por coluna, com
+// erro de rank tipicamente abaixo de 1% no centro e bem menor nas caudas.
+const tdigestCompression = 100
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// tdigest é um sketch de quantis mesclável (Dunning, "merging t-digest").
+// Pontos novos ficam num buffer e são comprimidos em centróides quando ele
+// enche; com poucos valores, cada centróide é um único ponto e os quantis
+// são exatos.
+type tdigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min, max    float64
+}
+
+func newTDigest(compression float64) *tdigest {
+	return &tdigest{compression: compression}
+}
+
+func (td *tdigest) add(x float64) {
+	td.addWeighted(x, 1)
+}
+
+func (td *tdigest) addWeighted(x, weight float64) {
+	if td.count == 0 || x < td.min {
+		td.min = x
+	}
+	if td.count == 0 || x > td.max {
+		td.max = x
+	}
+	td.count += weight
+	td.buffer = append(td.buffer, centroid{mean: x, weight: weight})
+	if len(td.buffer) >= int(5*td.compression) {
+		td.compress()
+	}
+}
+
+// merge incorpora os centróides de outro digest.
+func (td *tdigest) merge(other *tdigest) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	for _, c := range other.centroids {
+		td.addWeighted(c.mean, c.weight)
+	}
+	for _, c := range other.buffer {
+		td.addWeighted(c.mean, c.weight)
+	}
+	td.min = min(td.min, other.min)
+	td.max = max(td.max, other.max)
+}
+
+// scale é a função k1 do t-digest: centróides podem crescer enquanto
+// ocupam no máximo uma unidade de k, o que os mantém pequenos nas caudas.
+func (td *tdigest) scale(q float64) float64 {
+	return td.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+func (td *tdigest) compress() {
+	if len(td.buffer) == 0 {
+		return
+	}
+	all := append(td.centroids, td.buffer...)
+	td.buffer = td.buffer[:0]
+	slices.SortFunc(all, func(a, b centroid) int {
+		switch {
+		case a.mean < b.mean:
+			return -1
+		case a.mean > b.mean:
+			return 1
+		}
+		return 0
+	})
+
+	merged := make([]centroid, 0, len(all))
+	current := all[0]
+	weightSoFar := 0.0
+	for _, c := range all[1:] {
+		qLeft := weightSoFar / td.count
+		qRight := (weightSoFar + current.weight + c.weight) / td.count
+		if td.scale(min(qRight, 1))-td.scale(qLeft) <= 1 {
+			total := current.weight + c.weight
+			current.mean += (c.mean - current.mean) * c.weight / total
+			current.weight = total
+			continue
+		}
+		merged = append(merged, current)
+		weightSoFar += current.weight
+		current = c
+	}
+	td.centroids = append(merged, current)
+}
+
+// quantile interpola linearamente entre os centros dos centróides, tratando
+// o menor e o maior valor como pontos isolados nas pontas (mesma definição
+// do pandas quando cada centróide é um único valor).
+func (td *tdigest) quantile(q float64) float64 {
+	td.compress()
+	if td.count == 0 {
+		return math.NaN()
+	}
+	if q <= 0 {
+		return td.min
+	}
+	if q >= 1 {
+		return td.max
+	}
+
+	rank := q * (td.count - 1)
+
+	prevRank, prevValue := 0.0, td.min
+	cumulative := 0.0
+	for _, c := range td.centroids {
+		center := cumulative + (c.weight-1)/2
+		if rank <= center {
+			if center == prevRank {
+				return c.mean
+			}
+			return prevValue + (c.mean-prevValue)*(rank-prevRank)/(center-prevRank)
+		}
+		prevRank, prevValue = center, c.mean
+		cumulative += c.weight
+	}
+
+	last := td.count - 1
+	if last == prevRank {
+		return td.max
+	}
+	return prevValue + (td.max-prevValue)*(rank-prevRank)/(last-prevRank)
+}
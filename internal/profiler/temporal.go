@@ -0,0 +1,296 @@
+package profiler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limites de plausibilidade para epochs (2000-01-01 a 2100-01-01), evitando
+// que IDs numéricos de 10 ou 13 dígitos sejam lidos como timestamps.
+const (
+	epochMinSeconds = 946684800
+	epochMaxSeconds = 4102444800
+)
+
+// TemporalProfile resume colunas de data, data/hora, hora e epoch.
+type TemporalProfile struct {
+	Min       string         `json:"min"`
+	Max       string         `json:"max"`
+	Span      string         `json:"span"`
+	SpanDays  float64        `json:"span_days"`
+	ByWeekday map[string]int `json:"by_weekday,omitempty"`
+	ByHour    map[string]int `json:"by_hour,omitempty"`
+}
+
+var datetimeIsoLayouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04Z0700",
+	"2006-01-02T15:04",
+}
+
+// Leituras DD/MM e MM/DD das datas com barra, na ordem de monthFirst.
+var (
+	dateBrLayouts     = [2][]string{{"02/01/2006"}, {"01/02/2006"}}
+	datetimeBrLayouts = [2][]string{
+		{"02/01/2006 15:04:05", "02/01/2006 15:04"},
+		{"01/02/2006 15:04:05", "01/02/2006 15:04"},
+	}
+)
+
+var timeLayouts = []string{"15:04:05", "15:04"}
+
+// ParseTemporal converte um valor já classificado como tipo temporal em
+// time.Time. hasDate e hasClock indicam quais componentes o valor possui.
+// Datas com barra são lidas como DD/MM quando essa leitura existe.
+func ParseTemporal(t DataType, value string) (parsed time.Time, hasDate bool, hasClock bool, ok bool) {
+	reading, ok := parseTemporal(t, value, false)
+	if !ok && isSlashDate(value) {
+		reading, ok = parseTemporal(t, value, true)
+	}
+	return reading.at, reading.hasDate, reading.hasClock, ok
+}
+
+// temporalReading é um valor temporal interpretado. zoned indica se o valor
+// traz fuso (Z, -03:00 ou epoch); sem fuso, at fica em UTC só por convenção.
+type temporalReading struct {
+	at                       time.Time
+	hasDate, hasClock, zoned bool
+}
+
+// parseTemporal lê o valor; monthFirst escolhe a leitura MM/DD das datas com
+// barra.
+func parseTemporal(t DataType, value string, monthFirst bool) (temporalReading, bool) {
+	order := 0
+	if monthFirst {
+		order = 1
+	}
+	switch t {
+	case TypeDate:
+		layouts := append([]string{"2006-01-02"}, dateBrLayouts[order]...)
+		parsed, _, ok := parseFirst(value, layouts...)
+		return temporalReading{at: parsed, hasDate: true}, ok
+	case TypeDateCompact:
+		parsed, _, ok := parseFirst(value, "20060102", "02012006")
+		return temporalReading{at: parsed, hasDate: true}, ok
+	case TypeDatetime:
+		iso := value
+		if len(value) > 10 && value[10] == ' ' {
+			iso = value[:10] + "T" + value[11:]
+		}
+		parsed, layout, ok := parseFirst(iso, datetimeIsoLayouts...)
+		if !ok {
+			parsed, layout, ok = parseFirst(value, datetimeBrLayouts[order]...)
+		}
+		zoned := strings.Contains(layout, "Z07")
+		return temporalReading{at: parsed, hasDate: true, hasClock: true, zoned: zoned}, ok
+	case TypeTime:
+		parsed, _, ok := parseFirst(value, timeLayouts...)
+		return temporalReading{at: parsed, hasClock: true}, ok
+	case TypeEpoch:
+		parsed, ok := parseEpoch(value)
+		return temporalReading{at: parsed, hasDate: true, hasClock: true, zoned: true}, ok
+	}
+	return temporalReading{}, false
+}
+
+func parseFirst(value string, layouts ...string) (time.Time, string, bool) {
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, layout, true
+		}
+	}
+	return time.Time{}, "", false
+}
+
+// isSlashDate indica se o valor começa com uma data DD/MM/AAAA ou
+// MM/DD/AAAA.
+func isSlashDate(value string) bool {
+	return len(value) >= 10 && value[2] == '/' && value[5] == '/'
+}
+
+// isValidDatetime reprova data/hora bem formada mas inexistente no
+// calendário (31/02/2024 10:00) em qualquer das leituras.
+func isValidDatetime(value string) bool {
+	_, _, _, ok := ParseTemporal(TypeDatetime, value)
+	return ok
+}
+
+// parseEpoch aceita segundos (10 dígitos) ou milissegundos (13 dígitos)
+// dentro da janela plausível.
+func parseEpoch(value string) (time.Time, bool) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var parsed time.Time
+	switch len(value) {
+	case 10:
+		parsed = time.Unix(n, 0).UTC()
+	case 13:
+		parsed = time.UnixMilli(n).UTC()
+	default:
+		return time.Time{}, false
+	}
+
+	if parsed.Unix() < epochMinSeconds || parsed.Unix() >= epochMaxSeconds {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+func isEpoch(value string) bool {
+	_, ok := parseEpoch(value)
+	return ok
+}
+
+// temporalRange acumula os extremos e as distribuições de uma leitura da
+// coluna.
+type temporalRange struct {
+	count     int
+	min, max  temporalReading
+	byWeekday map[string]int
+	byHour    map[string]int
+}
+
+func newTemporalRange() *temporalRange {
+	return &temporalRange{
+		byWeekday: make(map[string]int),
+		byHour:    make(map[string]int),
+	}
+}
+
+func (tr *temporalRange) add(reading temporalReading) {
+	if tr.count == 0 || reading.at.Before(tr.min.at) {
+		tr.min = reading
+	}
+	if tr.count == 0 || reading.at.After(tr.max.at) {
+		tr.max = reading
+	}
+	tr.count++
+
+	if reading.hasDate {
+		tr.byWeekday[reading.at.Weekday().String()]++
+	}
+	if reading.hasClock {
+		tr.byHour[fmt.Sprintf("%02d", reading.at.Hour())]++
+	}
+}
+
+func (tr *temporalRange) merge(other *temporalRange) {
+	if other.count == 0 {
+		return
+	}
+	if tr.count == 0 || other.min.at.Before(tr.min.at) {
+		tr.min = other.min
+	}
+	if tr.count == 0 || other.max.at.After(tr.max.at) {
+		tr.max = other.max
+	}
+	tr.count += other.count
+	mergeCounts(tr.byWeekday, other.byWeekday)
+	mergeCounts(tr.byHour, other.byHour)
+}
+
+// temporalTally mantém as duas leituras (DD/MM e MM/DD) das datas com barra,
+// já que a convenção da coluna só é conhecida no fim. Datas que só existem
+// numa leitura e valores sem barra entram nas duas.
+type temporalTally struct {
+	readings     [2]*temporalRange
+	dayMonthOnly int
+	monthDayOnly int
+}
+
+func newTemporalTally() *temporalTally {
+	return &temporalTally{readings: [2]*temporalRange{newTemporalRange(), newTemporalRange()}}
+}
+
+func (tt *temporalTally) add(t DataType, value string) {
+	dayMonth, okDayMonth := parseTemporal(t, value, false)
+	if !isSlashDate(value) {
+		if okDayMonth {
+			tt.readings[0].add(dayMonth)
+			tt.readings[1].add(dayMonth)
+		}
+		return
+	}
+
+	monthDay, okMonthDay := parseTemporal(t, value, true)
+	switch {
+	case okDayMonth && okMonthDay:
+		tt.readings[0].add(dayMonth)
+		tt.readings[1].add(monthDay)
+	case okDayMonth:
+		tt.dayMonthOnly++
+		tt.readings[0].add(dayMonth)
+		tt.readings[1].add(dayMonth)
+	case okMonthDay:
+		tt.monthDayOnly++
+		tt.readings[0].add(monthDay)
+		tt.readings[1].add(monthDay)
+	}
+}
+
+func (tt *temporalTally) merge(other *temporalTally) {
+	tt.readings[0].merge(other.readings[0])
+	tt.readings[1].merge(other.readings[1])
+	tt.dayMonthOnly += other.dayMonthOnly
+	tt.monthDayOnly += other.monthDayOnly
+}
+
+// profile usa a leitura da convenção predominante da coluna: MM/DD só quando
+// as datas que só existem como MM/DD são maioria.
+func (tt *temporalTally) profile(t DataType) *TemporalProfile {
+	if tt == nil {
+		return nil
+	}
+	tr := tt.readings[0]
+	if tt.monthDayOnly > tt.dayMonthOnly {
+		tr = tt.readings[1]
+	}
+	if tr.count == 0 {
+		return nil
+	}
+
+	span := tr.max.at.Sub(tr.min.at)
+	profile := &TemporalProfile{
+		Min:      formatTemporal(t, tr.min),
+		Max:      formatTemporal(t, tr.max),
+		Span:     span.String(),
+		SpanDays: span.Hours() / 24,
+	}
+	if len(tr.byWeekday) > 0 {
+		profile.ByWeekday = tr.byWeekday
+	}
+	if len(tr.byHour) > 0 {
+		profile.ByHour = tr.byHour
+	}
+	return profile
+}
+
+// formatTemporal escreve o valor em ISO 8601, sem fuso quando a origem não
+// tinha fuso.
+func formatTemporal(t DataType, reading temporalReading) string {
+	switch {
+	case t == TypeDate || t == TypeDateCompact:
+		return reading.at.Format("2006-01-02")
+	case t == TypeTime:
+		return reading.at.Format("15:04:05")
+	case !reading.zoned:
+		return reading.at.Format("2006-01-02T15:04:05")
+	}
+	return reading.at.Format(time.RFC3339)
+}
+
+func isTemporal(t DataType) bool {
+	switch t {
+	case TypeDate, TypeDateCompact, TypeDatetime, TypeTime, TypeEpoch:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package profiler
+
+import "testing"
+
+func TestTemporalProfile(t *testing.T) {
+	t.Run("Datetime com distribuição por dia da semana e hora", func(t *testing.T) {
+		acc := NewColumnAccumulator("evento")
+		acc.Add("2024-05-01T13:45:00Z") // quarta
+		acc.Add("2024-05-03T08:00:00Z") // sexta
+		acc.Add("2024-05-01T13:10:00Z") // quarta
+
+		result := acc.Result()
+
+		if result.MainType != TypeDatetime {
+			t.Fatalf("Esperava MainType DATETIME, recebeu %s", result.MainType)
+		}
+		profile := result.TemporalProfile
+		if profile == nil {
+			t.Fatal("TemporalProfile não deveria ser nil")
+		}
+		if profile.Min != "2024-05-01T13:10:00Z" || profile.Max != "2024-05-03T08:00:00Z" {
+			t.Errorf("Min/Max incorretos: %s / %s", profile.Min, profile.Max)
+		}
+		if profile.Span != "42h50m0s" {
+			t.Errorf("Span incorreto: %s", profile.Span)
+		}
+		if profile.ByWeekday["Wednesday"] != 2 || profile.ByWeekday["Friday"] != 1 {
+			t.Errorf("Distribuição por dia da semana incorreta: %v", profile.ByWeekday)
+		}
+		if profile.ByHour["13"] != 2 || profile.ByHour["08"] != 1 {
+			t.Errorf("Distribuição por hora incorreta: %v", profile.ByHour)
+		}
+	})
+
+	t.Run("Epoch em milissegundos", func(t *testing.T) {
+		acc := NewColumnAccumulator("event_timestamp")
+		acc.Add("1714571100000")
+		acc.Add("1714657500000")
+
+		result := acc.Result()
+
+		if result.MainType != TypeEpoch {
+			t.Fatalf("Esperava MainType EPOCH, recebeu %s", result.MainType)
+		}
+		if result.TemporalProfile == nil || result.TemporalProfile.SpanDays != 1 {
+			t.Errorf("Esperava span de 1 dia, recebeu %+v", result.TemporalProfile)
+		}
+	})
+
+	t.Run("Data sem hora não gera distribuição por hora", func(t *testing.T) {
+		result := AnalyzeColumn(Column{Name: "dt", Values: []string{"25/12/2023", "2024-01-01"}})
+
+		if result.TemporalProfile == nil {
+			t.Fatal("TemporalProfile não deveria ser nil para DATE")
+		}
+		if result.TemporalProfile.ByHour != nil {
+			t.Errorf("DATE não deveria ter distribuição por hora: %v", result.TemporalProfile.ByHour)
+		}
+		if result.TemporalProfile.Min != "2023-12-25" {
+			t.Errorf("Min incorreto: %s", result.TemporalProfile.Min)
+		}
+	})
+
+	t.Run("Data/hora com barra segue a convenção da coluna", func(t *testing.T) {
+		acc := NewColumnAccumulator("evento")
+		acc.Add("12/25/2023 10:00") // só MM/DD
+		acc.Add("01/02/2024 08:30") // ambígua: 2 de janeiro em MM/DD
+		acc.Add("12/31/2023 23:00") // só MM/DD
+
+		profile := acc.Result().TemporalProfile
+		if profile == nil {
+			t.Fatal("TemporalProfile não deveria ser nil")
+		}
+		if profile.Min != "2023-12-25T10:00:00" || profile.Max != "2024-01-02T08:30:00" {
+			t.Errorf("Min/Max deveriam seguir MM/DD e sem fuso: %s / %s", profile.Min, profile.Max)
+		}
+	})
+
+	t.Run("Data/hora inexistente conta como inválida", func(t *testing.T) {
+		for mode, result := range analyzeBoth("evento", []string{"31/01/2024 10:00", "31/02/2024 10:00", "2024-02-30T10:00:00Z"}) {
+			if result.MainType != TypeDatetime || result.ValidCount != 1 || result.InvalidCount != 2 {
+				t.Errorf("[%s] tipo %s, válidos %d, inválidos %d", mode, result.MainType, result.ValidCount, result.InvalidCount)
+			}
+			if result.TemporalProfile == nil || result.TemporalProfile.Min != "2024-01-31T10:00:00" {
+				t.Errorf("[%s] perfil deveria ignorar as inválidas: %+v", mode, result.TemporalProfile)
+			}
+		}
+	})
+
+	t.Run("Fuso preservado só quando existe", func(t *testing.T) {
+		acc := NewColumnAccumulator("evento")
+		acc.Add("2024-05-01 13:45:00")
+		acc.Add("2024-05-02T13:45:00-03:00")
+
+		profile := acc.Result().TemporalProfile
+		if profile.Min != "2024-05-01T13:45:00" || profile.Max != "2024-05-02T13:45:00-03:00" {
+			t.Errorf("Min/Max: %s / %s", profile.Min, profile.Max)
+		}
+	})
+
+	t.Run("Todo fuso aceito pelo scanner é lido", func(t *testing.T) {
+		values := []string{
+			"2024-01-02T13:45Z", "2024-01-02T13:45-03:00", "2024-01-02T13:45-0300", "2024-01-02 13:45+0300",
+			"2024-01-02T13:45:00-0300", "2024-01-02T13:45:00.123+03:00", "2024-01-02 13:45:00",
+		}
+		for _, value := range values {
+			if !matchDatetimeIso(value) {
+				t.Fatalf("%q deveria casar com o scanner", value)
+			}
+			if !isValidDatetime(value) {
+				t.Errorf("%q casa com o scanner mas não é lido", value)
+			}
+		}
+	})
+
+	t.Run("Colunas não temporais não geram perfil", func(t *testing.T) {
+		acc := NewColumnAccumulator("qtd")
+		acc.Add("10")
+
+		if acc.Result().TemporalProfile != nil {
+			t.Error("Coluna INTEGER não deveria ter TemporalProfile")
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package profiler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextProfile resume comprimento e classes de caracteres dos valores
+// preenchidos de uma coluna, medidos antes do trim, como chegariam ao banco.
+type TextProfile struct {
+	MinLength       int            `json:"min_length"`  // em caracteres
+	MaxLength       int            `json:"max_length"`  // em caracteres
+	MaxBytes        int            `json:"max_bytes"`   // em bytes UTF-8, para dimensionar VARCHAR
+	MeanLength      float64        `json:"mean_length"` // em caracteres
+	LengthHistogram map[string]int `json:"length_histogram"`
+
+	LeadingTrailingSpace int `json:"leading_trailing_space"` // espaços nas pontas
+	NonBreakingSpace     int `json:"non_breaking_space"`     // U+00A0, U+2007, U+202F
+	ControlChars         int `json:"control_chars"`          // tabulação, quebra de linha etc.
+	MixedCase            int `json:"mixed_case"`             // maiúsculas e minúsculas no mesmo valor
+	Accented             int `json:"accented"`               // letras fora do ASCII
+	Mojibake             int `json:"mojibake"`               // UTF-8 lido como Windows-1252 ("Ã§") ou U+FFFD
+}
+
+// windows1252High são os caracteres que o Windows-1252 coloca em 0x80-0x9F;
+// aparecem como segundo caractere quando UTF-8 é lido nessa codificação.
+const windows1252High = "€‚ƒ„…†‡ˆ‰Š‹ŒŽ‘’“”•–—˜™š›œžŸ"
+
+type textTally struct {
+	count    int
+	minLen   int
+	maxLen   int
+	maxBytes int
+	sumLen   int
+	lengths  map[string]int
+
+	surrounding int
+	nbsp        int
+	control     int
+	mixedCase   int
+	accented    int
+	mojibake    int
+}
+
+func newTextTally() *textTally {
+	return &textTally{lengths: make(map[string]int)}
+}
+
+func (tt *textTally) add(raw string) {
+	length := utf8.RuneCountInString(raw)
+	if tt.count == 0 || length < tt.minLen {
+		tt.minLen = length
+	}
+	tt.maxLen = max(tt.maxLen, length)
+	tt.maxBytes = max(tt.maxBytes, len(raw))
+	tt.sumLen += length
+	tt.count++
+	tt.lengths[lengthBucket(length)]++
+
+	if strings.TrimSpace(raw) != raw {
+		tt.surrounding++
+	}
+
+	var hasNBSP, hasControl, hasUpper, hasLower, hasAccent, hasMojibake bool
+	var prev rune
+	for _, r := range raw {
+		switch {
+		case r == '\u00a0' || r == '\u2007' || r == '\u202f':
+			hasNBSP = true
+		case unicode.IsControl(r):
+			hasControl = true
+		case r == utf8.RuneError:
+			hasMojibake = true
+		}
+		if unicode.IsUpper(r) {
+			hasUpper = true
+		} else if unicode.IsLower(r) {
+			hasLower = true
+		}
+		if r > unicode.MaxASCII && unicode.IsLetter(r) {
+			hasAccent = true
+		}
+		if isMojibakePair(prev, r) {
+			hasMojibake = true
+		}
+		prev = r
+	}
+
+	tt.nbsp += boolCount(hasNBSP)
+	tt.control += boolCount(hasControl)
+	tt.mixedCase += boolCount(hasUpper && hasLower)
+	tt.accented += boolCount(hasAccent)
+	tt.mojibake += boolCount(hasMojibake)
+}
+
+// isMojibakePair reconhece o par de caracteres que um caractere UTF-8 de dois
+// ou três bytes vira quando decodificado como Windows-1252: "Ã§" (ç), "Ã©"
+// (é), "Âº" (º), "â€" (aspas e travessões).
+func isMojibakePair(prev, r rune) bool {
+	switch prev {
+	case 'Ã', 'Â':
+		return (r >= 0x80 && r <= 0xbf) || strings.ContainsRune(windows1252High, r)
+	case 'â':
+		return r == '€'
+	}
+	return false
+}
+
+func (tt *textTally) merge(other *textTally) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	if tt.count == 0 || other.minLen < tt.minLen {
+		tt.minLen = other.minLen
+	}
+	tt.maxLen = max(tt.maxLen, other.maxLen)
+	tt.maxBytes = max(tt.maxBytes, other.maxBytes)
+	tt.sumLen += other.sumLen
+	tt.count += other.count
+	mergeCounts(tt.lengths, other.lengths)
+
+	tt.surrounding += other.surrounding
+	tt.nbsp += other.nbsp
+	tt.control += other.control
+	tt.mixedCase += other.mixedCase
+	tt.accented += other.accented
+	tt.mojibake += other.mojibake
+}
+
+// lengthBucket usa o comprimento exato até 32 caracteres e faixas de potência
+// de dois acima disso ("33-64", "65-128"...).
+func lengthBucket(length int) string {
+	if length <= 32 {
+		return strconv.Itoa(length)
+	}
+	upper := 64
+	for length > upper {
+		upper *= 2
+	}
+	return fmt.Sprintf("%d-%d", upper/2+1, upper)
+}
+
+func boolCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (tt *textTally) profile() *TextProfile {
+	if tt == nil || tt.count == 0 {
+		return nil
+	}
+	return &TextProfile{
+		MinLength:            tt.minLen,
+		MaxLength:            tt.maxLen,
+		MaxBytes:             tt.maxBytes,
+		MeanLength:           float64(tt.sumLen) / float64(tt.count),
+		LengthHistogram:      tt.lengths,
+		LeadingTrailingSpace: tt.surrounding,
+		NonBreakingSpace:     tt.nbsp,
+		ControlChars:         tt.control,
+		MixedCase:            tt.mixedCase,
+		Accented:             tt.accented,
+		Mojibake:             tt.mojibake,
+	}
+}
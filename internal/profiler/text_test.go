@@ -0,0 +1,84 @@
+package profiler
+
+import "testing"
+
+func TestTextProfile(t *testing.T) {
+	values := []string{
+		"São Paulo",
+		" Campinas",      // espaço à esquerda
+		"Ribeirão Preto", // espaço não separável
+		"SANTOS\t",       // controle (e espaço nas pontas)
+		"AÃ§ailÃ¢ndia",   // mojibake de "Açailândia"
+		"Itu",
+		"",
+	}
+
+	acc := NewColumnAccumulator("cidade")
+	for _, v := range values {
+		acc.Add(v)
+	}
+	streaming := acc.Result()
+	sync := AnalyzeColumn(Column{Name: "cidade", Values: values})
+
+	for name, result := range map[string]ColumnResult{"streaming": streaming, "sync": sync} {
+		p := result.TextProfile
+		if p == nil {
+			t.Fatalf("[%s] TextProfile não deveria ser nil", name)
+		}
+
+		checks := []struct {
+			field    string
+			got      int
+			expected int
+		}{
+			{"MinLength", p.MinLength, 3},
+			{"MaxLength", p.MaxLength, 14},
+			{"MaxBytes", p.MaxBytes, 16},
+			{"LeadingTrailingSpace", p.LeadingTrailingSpace, 2},
+			{"NonBreakingSpace", p.NonBreakingSpace, 1},
+			{"ControlChars", p.ControlChars, 1},
+			{"MixedCase", p.MixedCase, 5},
+			{"Accented", p.Accented, 3},
+			{"Mojibake", p.Mojibake, 1},
+			{"LengthHistogram[9]", p.LengthHistogram["9"], 2},
+		}
+		for _, c := range checks {
+			if c.got != c.expected {
+				t.Errorf("[%s] %s = %d; esperava %d", name, c.field, c.got, c.expected)
+			}
+		}
+		if p.MeanLength != 9 {
+			t.Errorf("[%s] MeanLength = %f; esperava 9", name, p.MeanLength)
+		}
+	}
+}
+
+func TestIsMojibakePair(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"CORAÃ‡ÃƒO", true},  // CORAÇÃO
+		{"nÂº 10", true},     // nº 10
+		{"â€œaspasâ€", true}, // “aspas”
+		{"Ãgua", false},
+		{"São João", false},
+	}
+
+	for _, tt := range tests {
+		tally := newTextTally()
+		tally.add(tt.value)
+		if got := tally.mojibake == 1; got != tt.expected {
+			t.Errorf("mojibake(%q) = %v; esperava %v", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestLengthBucket(t *testing.T) {
+	tests := map[int]string{0: "0", 14: "14", 32: "32", 33: "33-64", 64: "33-64", 65: "65-128", 300: "257-512"}
+	for length, expected := range tests {
+		if got := lengthBucket(length); got != expected {
+			t.Errorf("lengthBucket(%d) = %q; esperava %q", length, got, expected)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package profiler
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+)
+
+const (
+	CardinalityEnum        = "ENUM"
+	CardinalityCategorical = "CATEGORICAL"
+)
+
+// Quantidade padrão de valores mais frequentes reportados por coluna. O
+// sketch monitora topKCapacityFactor vezes mais valores para que a ordem do
+// topo seja confiável.
+const (
+	defaultTopK        = 10
+	topKCapacityFactor = 10
+)
+
+// ValueCount é um valor frequente com sua contagem aproximada. A contagem
+// real fica entre Count-Error e Count.
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+	Error int    `json:"error,omitempty"`
+}
+
+type heavyHitter struct {
+	value   string
+	example string
+	count   int
+	err     int
+	index   int
+}
+
+type hitterHeap []*heavyHitter
+
+func (h hitterHeap) Len() int           { return len(h) }
+func (h hitterHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+func (h hitterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *hitterHeap) Push(x any) {
+	item := x.(*heavyHitter)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *hitterHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// spaceSaving mantém os valores mais frequentes em memória limitada
+// (algoritmo Space-Saving de Metwally et al.): quando o sketch está cheio, o
+// valor novo substitui o menos frequente e herda sua contagem como erro.
+type spaceSaving struct {
+	capacity int
+	items    map[string]*heavyHitter
+	heap     hitterHeap
+}
+
+func newSpaceSaving(capacity int) *spaceSaving {
+	return &spaceSaving{
+		capacity: capacity,
+		items:    make(map[string]*heavyHitter, capacity),
+	}
+}
+
+func (s *spaceSaving) add(value string) {
+	s.addWithExample(value, "")
+}
+
+// addWithExample guarda, junto à chave, o primeiro valor observado desde que
+// ela entrou no sketch.
+func (s *spaceSaving) addWithExample(value, example string) {
+	if s.capacity == 0 {
+		return
+	}
+	if item, ok := s.items[value]; ok {
+		item.count++
+		heap.Fix(&s.heap, item.index)
+		return
+	}
+
+	if len(s.heap) < s.capacity {
+		item := &heavyHitter{value: value, example: example, count: 1}
+		s.items[value] = item
+		heap.Push(&s.heap, item)
+		return
+	}
+
+	evicted := s.heap[0]
+	delete(s.items, evicted.value)
+	evicted.value = value
+	evicted.example = example
+	evicted.err = evicted.count
+	evicted.count++
+	s.items[value] = evicted
+	heap.Fix(&s.heap, 0)
+}
+
+// merge combina outro sketch (resumos mescláveis de Agarwal et al.): um
+// valor ausente de um dos lados recebe a menor contagem daquele lado, se ele
+// estiver cheio, como contagem e erro. Ficam os capacity mais frequentes.
+func (s *spaceSaving) merge(other *spaceSaving) {
+	if other == nil || len(other.heap) == 0 || s.capacity == 0 {
+		return
+	}
+	floor, otherFloor := s.floor(), other.floor()
+
+	merged := make([]*heavyHitter, 0, len(s.heap)+len(other.heap))
+	for _, item := range s.heap {
+		combined := *item
+		if o, ok := other.items[item.value]; ok {
+			combined.count += o.count
+			combined.err += o.err
+		} else {
+			combined.count += otherFloor
+			combined.err += otherFloor
+		}
+		merged = append(merged, &combined)
+	}
+	for _, o := range other.heap {
+		if _, ok := s.items[o.value]; ok {
+			continue
+		}
+		combined := *o
+		combined.count += floor
+		combined.err += floor
+		merged = append(merged, &combined)
+	}
+
+	slices.SortFunc(merged, func(a, b *heavyHitter) int {
+		if c := cmp.Compare(b.count, a.count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.value, b.value)
+	})
+	merged = merged[:min(len(merged), s.capacity)]
+
+	s.items = make(map[string]*heavyHitter, s.capacity)
+	s.heap = s.heap[:0]
+	for _, item := range merged {
+		s.items[item.value] = item
+		heap.Push(&s.heap, item)
+	}
+}
+
+// floor é a menor contagem do sketch cheio (zero se ainda há espaço).
+func (s *spaceSaving) floor() int {
+	if len(s.heap) < s.capacity {
+		return 0
+	}
+	return s.heap[0].count
+}
+
+// ranked retorna os n itens mais frequentes, do maior para o menor.
+func (s *spaceSaving) ranked(n int) []heavyHitter {
+	if len(s.heap) == 0 || n <= 0 {
+		return nil
+	}
+	items := make([]heavyHitter, 0, len(s.heap))
+	for _, item := range s.heap {
+		items = append(items, *item)
+	}
+	slices.SortFunc(items, func(a, b heavyHitter) int {
+		if c := cmp.Compare(b.count, a.count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.value, b.value)
+	})
+	return items[:min(n, len(items))]
+}
+
+func (s *spaceSaving) top(n int) []ValueCount {
+	items := s.ranked(n)
+	if items == nil {
+		return nil
+	}
+	values := make([]ValueCount, len(items))
+	for i, item := range items {
+		values[i] = ValueCount{Value: item.value, Count: item.count, Error: item.err}
+	}
+	return values
+}
+
+// classifyCardinality marca colunas discretas de baixa cardinalidade: ENUM
+// para até 10 valores distintos e CATEGORICAL para até 1000 distintos que
+// representem no máximo 5% das linhas preenchidas. Colunas contínuas
+// (decimais, moeda, datas) e amostras com menos de 20 valores não são
+// classificadas.
+func classifyCardinality(t DataType, distinct, filled int) string {
+	if filled < 20 || (isNumericType(t) && t != TypeInteger) || isTemporal(t) {
+		return ""
+	}
+	uniqueness := float64(distinct) / float64(filled)
+	switch {
+	case distinct <= 10 && uniqueness <= 0.5:
+		return CardinalityEnum
+	case distinct <= 1000 && uniqueness <= 0.05:
+		return CardinalityCategorical
+	}
+	return ""
+}
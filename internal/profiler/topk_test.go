@@ -0,0 +1,134 @@
+package profiler
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestSpaceSaving_Top(t *testing.T) {
+	s := newSpaceSaving(20)
+	// Três valores dominantes misturados a 5000 valores raros.
+	for i := 0; i < 5000; i++ {
+		s.add("raro-" + strconv.Itoa(i))
+		if i%2 == 0 {
+			s.add("SP")
+		}
+		if i%5 == 0 {
+			s.add("RJ")
+		}
+		if i%10 == 0 {
+			s.add("S.P.")
+		}
+	}
+
+	top := s.top(3)
+	expected := []string{"SP", "RJ", "S.P."}
+	if len(top) != 3 {
+		t.Fatalf("Esperava 3 valores, recebeu %v", top)
+	}
+	for i, want := range expected {
+		if top[i].Value != want {
+			t.Errorf("Posição %d: esperava %s, recebeu %s", i, want, top[i].Value)
+		}
+	}
+	// A contagem aproximada nunca subestima e o erro delimita a real.
+	if top[0].Count < 2500 || top[0].Count-top[0].Error > 2500 {
+		t.Errorf("Contagem de SP fora do limite: %+v", top[0])
+	}
+}
+
+func TestTopValuesAndCardinality(t *testing.T) {
+	var values []string
+	for i := 0; i < 100; i++ {
+		switch {
+		case i%10 < 6:
+			values = append(values, "ATIVO")
+		case i%10 < 9:
+			values = append(values, "INATIVO")
+		default:
+			values = append(values, "ATIVOO")
+		}
+	}
+
+	acc := NewColumnAccumulator("status")
+	for _, v := range values {
+		acc.Add(v)
+	}
+	streaming := acc.Result()
+	sync := AnalyzeColumn(Column{Name: "status", Values: values})
+
+	for name, result := range map[string]ColumnResult{"streaming": streaming, "sync": sync} {
+		if result.CardinalityClass != CardinalityEnum {
+			t.Errorf("[%s] Esperava ENUM, recebeu %q", name, result.CardinalityClass)
+		}
+		expected := []ValueCount{{Value: "ATIVO", Count: 60}, {Value: "INATIVO", Count: 30}, {Value: "ATIVOO", Count: 10}}
+		if len(result.TopValues) != len(expected) {
+			t.Fatalf("[%s] TopValues inesperado: %v", name, result.TopValues)
+		}
+		for i, want := range expected {
+			if result.TopValues[i] != want {
+				t.Errorf("[%s] TopValues[%d] = %+v; esperava %+v", name, i, result.TopValues[i], want)
+			}
+		}
+	}
+}
+
+func TestClassifyCardinality(t *testing.T) {
+	tests := []struct {
+		name     string
+		dtype    DataType
+		distinct int
+		filled   int
+		expected string
+	}{
+		{"Status", TypeString, 4, 1000, CardinalityEnum},
+		{"UF", TypeString, 27, 100_000, CardinalityCategorical},
+		{"Código de Produto", TypeInteger, 800, 50_000, CardinalityCategorical},
+		{"Texto Livre", TypeString, 9000, 10_000, ""},
+		{"Amostra Pequena", TypeString, 2, 10, ""},
+		{"Valor Monetário", TypeCurrencyBRL, 5, 1000, ""},
+		{"Data", TypeDate, 5, 1000, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyCardinality(tt.dtype, tt.distinct, tt.filled); got != tt.expected {
+				t.Errorf("classifyCardinality = %q; esperava %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithTopK(t *testing.T) {
+	values := []string{"A", "B", "B", "C", "C", "C"}
+
+	if got := AnalyzeColumn(Column{Name: "x", Values: values}, WithTopK(2)).TopValues; len(got) != 2 || got[0].Value != "C" {
+		t.Errorf("Esperava os 2 mais frequentes, recebeu %v", got)
+	}
+	if got := AnalyzeColumn(Column{Name: "x", Values: values}, WithTopK(0)).TopValues; got != nil {
+		t.Errorf("WithTopK(0) deveria desativar o ranking, recebeu %v", got)
+	}
+}
+
+func TestWithTopPatterns(t *testing.T) {
+	values := []string{"A", "BB", "BB", "123", "123", "123"}
+
+	for mode, result := range analyzeBoth("x", values, WithTopK(0)) {
+		if result.TopValues != nil {
+			t.Errorf("[%s] WithTopK(0) deveria desativar os valores, recebeu %v", mode, result.TopValues)
+		}
+		if len(result.TopPatterns) != 3 {
+			t.Errorf("[%s] WithTopK(0) não deveria afetar os formatos, recebeu %v", mode, result.TopPatterns)
+		}
+	}
+	for mode, result := range analyzeBoth("x", values, WithTopPatterns(1)) {
+		if len(result.TopPatterns) != 1 || result.TopPatterns[0].Pattern != "999" || len(result.TopValues) != 3 {
+			t.Errorf("[%s] Esperava só o formato 999 e os 3 valores, recebeu %v e %v", mode, result.TopPatterns, result.TopValues)
+		}
+	}
+	for mode, result := range analyzeBoth("x", values, WithTopPatterns(0)) {
+		if result.TopPatterns != nil {
+			t.Errorf("[%s] WithTopPatterns(0) deveria desativar os formatos, recebeu %v", mode, result.TopPatterns)
+		}
+	}
+}
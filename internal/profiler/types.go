@@ -3,19 +3,32 @@ package profiler
 type DataType string
 
 const (
-	TypeEmpty   DataType = "EMPTY"
-	TypeInteger DataType = "INTEGER"
-	TypeFloat   DataType = "FLOAT"
-	TypeBoolean DataType = "BOOLEAN"
-	TypeString  DataType = "STRING"
+	TypeEmpty       DataType = "EMPTY"
+	TypeInteger     DataType = "INTEGER"
+	TypeFloat       DataType = "FLOAT"
+	TypeCurrencyBRL DataType = "CURRENCY_BRL"
+	TypePercentage  DataType = "PERCENTAGE"
+	TypeBoolean     DataType = "BOOLEAN"
+	TypeString      DataType = "STRING"
 
 	TypeFiscalKey44 DataType = "FISCAL_KEY_44"
 	TypeCNPJ        DataType = "CNPJ"
 	TypeCPF         DataType = "CPF"
 	TypePlaca       DataType = "LICENSE_PLATE"
+	TypeVIN         DataType = "VIN"
 	TypeNCM         DataType = "NCM"
 	TypeRNTRC       DataType = "RNTRC"
 	TypeEAN         DataType = "EAN_PRODUCT"
+	TypeCFOP        DataType = "CFOP"
+	TypeCST         DataType = "CST"
+	TypeCSOSN       DataType = "CSOSN"
+	TypeCEST        DataType = "CEST"
+
+	TypePIS           DataType = "PIS_PASEP"
+	TypeRENAVAM       DataType = "RENAVAM"
+	TypeCNH           DataType = "CNH"
+	TypeTituloEleitor DataType = "VOTER_ID"
+	TypeCNS           DataType = "CNS"
 
 	TypeContainer DataType = "CONTAINER_ID"
 	TypeCEP       DataType = "CEP"
@@ -24,6 +37,9 @@ const (
 
 	TypeDate        DataType = "DATE"
 	TypeDateCompact DataType = "DATE_COMPACT"
+	TypeDatetime    DataType = "DATETIME"
+	TypeTime        DataType = "TIME"
+	TypeEpoch       DataType = "EPOCH"
 )
 
 func (d DataType) String() string {
@@ -44,21 +60,8 @@ func (ds DataSensitivity) String() string {
 	return string(ds)
 }
 
+// ClassifySensitivity retorna a classificação declarada pelo detector do tipo
+// no registro padrão.
 func ClassifySensitivity(t DataType) (DataSensitivity, string) {
-	switch t {
-	case TypeCPF, TypeCNPJ:
-		return SensitivityConfidential, "Identificação Pessoal/Empresarial (PII)"
-	case TypeEmail, TypeMobile:
-		return SensitivityConfidential, "Dado de Contato Pessoal (PII)"
-	case TypeFiscalKey44:
-		return SensitivityConfidential, "Sigilo Fiscal (NFe/CTe)"
-
-	case TypePlaca, TypeRNTRC, TypeContainer:
-		return SensitivityInternal, "Rastreabilidade Logística (Segurança Operacional)"
-	case TypeEAN, TypeNCM:
-		return SensitivityInternal, "Inteligência Comercial/Produto"
-
-	default:
-		return SensitivityPublic, "Dado Geral / Não Classificado"
-	}
+	return defaultRegistry.Sensitivity(t)
 }